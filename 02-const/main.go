@@ -1,5 +1,10 @@
 package main
 
+import (
+	"fmt"
+	"strings"
+)
+
 const number = 5
 const explicitNumber int16 = 42
 const nickname = "GoDev"
@@ -26,6 +31,53 @@ const (
 	Exabyte
 )
 
+// Набор флагов на битах: каждая константа занимает свой бит
+type Flags uint
+
+const (
+	FlagRead Flags = 1 << iota
+	FlagWrite
+	FlagExecute
+	FlagAdmin
+)
+
+var flagNames = []struct {
+	flag Flags
+	name string
+}{
+	{FlagRead, "read"},
+	{FlagWrite, "write"},
+	{FlagExecute, "execute"},
+	{FlagAdmin, "admin"},
+}
+
+func (f *Flags) Set(flag Flags)   { *f |= flag }
+func (f *Flags) Clear(flag Flags) { *f &^= flag }
+
+// Установлены ли все биты flag; пустой набор не содержит ни одного флага,
+// поэтому Has(0) возвращает false
+func (f Flags) Has(flag Flags) bool { return flag != 0 && f&flag == flag }
+
+// Список включенных флагов через "|", для пустого набора — "none".
+// Биты без имени выводятся числом в конце: "read|Flags(0x40)".
+func (f Flags) String() string {
+	if f == 0 {
+		return "none"
+	}
+	var names []string
+	rest := f
+	for _, fn := range flagNames {
+		if f.Has(fn.flag) {
+			names = append(names, fn.name)
+			rest &^= fn.flag
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("Flags(%#x)", uint(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
 func main() {
 	euler := 2.718
 
@@ -37,4 +89,11 @@ func main() {
 	// invalid operation: mismatched types float64 and int16
 
 	println(Kilobyte, Megabyte, Gigabyte, Terabyte, Petabyte, Exabyte)
+
+	// Битовые флаги: несколько значений в одном числе
+	var perms Flags
+	println(perms.String())
+	perms.Set(FlagRead | FlagWrite | FlagAdmin)
+	perms.Clear(FlagAdmin)
+	println(perms.String(), perms.Has(FlagWrite), perms.Has(FlagAdmin))
 }
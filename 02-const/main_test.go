@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+func TestFlags(t *testing.T) {
+	var f Flags
+	if f.String() != "none" {
+		t.Errorf("empty Flags.String() = %q, expected \"none\"", f.String())
+	}
+
+	f.Set(FlagRead | FlagWrite)
+	f.Set(FlagAdmin)
+	f.Set(FlagRead) // Повторная установка ничего не меняет
+	if got := f.String(); got != "read|write|admin" {
+		t.Errorf("String() = %q, expected \"read|write|admin\"", got)
+	}
+
+	f.Clear(FlagWrite)
+	tests := []struct {
+		flag     Flags
+		expected bool
+	}{
+		{FlagRead, true},
+		{FlagWrite, false},
+		{FlagExecute, false},
+		{FlagAdmin, true},
+		{FlagRead | FlagAdmin, true},
+		{FlagRead | FlagWrite, false},
+	}
+	for _, test := range tests {
+		if got := f.Has(test.flag); got != test.expected {
+			t.Errorf("%v.Has(%v) = %v, expected %v", f, test.flag, got, test.expected)
+		}
+	}
+	if got := f.String(); got != "read|admin" {
+		t.Errorf("String() after Clear = %q, expected \"read|admin\"", got)
+	}
+
+	f.Clear(FlagRead | FlagAdmin)
+	if f != 0 || f.String() != "none" {
+		t.Errorf("Flags after clearing all = %d (%q), expected 0 (\"none\")", f, f.String())
+	}
+}
+
+func TestFlagsHasZero(t *testing.T) {
+	for _, f := range []Flags{0, FlagRead, FlagRead | FlagWrite | FlagExecute | FlagAdmin} {
+		if f.Has(0) {
+			t.Errorf("%v.Has(0) = true, expected false", f)
+		}
+	}
+}
+
+func TestFlagsStringUnknownBits(t *testing.T) {
+	tests := []struct {
+		flags    Flags
+		expected string
+	}{
+		{0x40, "Flags(0x40)"},
+		{FlagRead | 0x40, "read|Flags(0x40)"},
+		{FlagWrite | FlagAdmin | 0x30, "write|admin|Flags(0x30)"},
+		{FlagRead | FlagExecute, "read|execute"},
+	}
+	for _, test := range tests {
+		if got := test.flags.String(); got != test.expected {
+			t.Errorf("Flags(%#x).String() = %q, expected %q", uint(test.flags), got, test.expected)
+		}
+	}
+}
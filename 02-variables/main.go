@@ -1,6 +1,67 @@
 package main
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Склейка строк через strings.Builder с заранее вычисленным размером:
+// память выделяется один раз, а не на каждой итерации, как при +=
+func JoinStrings(parts []string, sep string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	size := len(sep) * (len(parts) - 1)
+	for _, part := range parts {
+		size += len(part)
+	}
+
+	var b strings.Builder
+	b.Grow(size)
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		b.WriteString(sep)
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// Целые числа в Go при переполнении молча "заворачиваются":
+// math.MaxInt64 + 1 == math.MinInt64. Функции ниже возвращают ошибку вместо этого.
+var ErrOverflow = errors.New("integer overflow")
+
+func AddChecked(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, ErrOverflow
+	}
+	return a + b, nil
+}
+
+func MulChecked(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	result := a * b
+	// -1 * MinInt64 не помещается в int64, а деление ниже этого не заметит
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || result/b != a {
+		return 0, ErrOverflow
+	}
+	return result, nil
+}
+
+// У MinInt64 нет положительной пары: -MinInt64 == MinInt64
+func NegChecked(a int64) (int64, error) {
+	if a == math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return -a, nil
+}
 
 func main() {
 	// Числовые переменные
@@ -36,6 +97,21 @@ func main() {
 	fmt.Println("Float to Integer:", int(pi))
 	fmt.Println("Integer to Character:", string(97)) // ASCII 'a'
 
+	// Переполнение целых чисел
+	var maxInt int64 = math.MaxInt64
+	fmt.Println("Silent overflow:", maxInt+1)
+	if _, err := AddChecked(math.MaxInt64, 1); err != nil {
+		fmt.Println("AddChecked(MaxInt64, 1):", err)
+	}
+	sum, err := AddChecked(math.MaxInt64-1, 1)
+	fmt.Println("AddChecked(MaxInt64-1, 1):", sum, err)
+	if _, err := MulChecked(math.MaxInt64/2+1, 2); err != nil {
+		fmt.Println("MulChecked(MaxInt64/2+1, 2):", err)
+	}
+	if _, err := NegChecked(math.MinInt64); err != nil {
+		fmt.Println("NegChecked(MinInt64):", err)
+	}
+
 	// Комплексные числа
 	complexNum := 1 + 4i
 	fmt.Println("Complex number:", complexNum)
@@ -46,6 +122,12 @@ func main() {
 	fullName := firstName + " " + lastName
 	fmt.Println("Full name and its length:", fullName, len(fullName))
 
+	// Склейка множества строк: strings.Builder против +=
+	words := strings.Fields(strings.Repeat("go is fun ", 200))
+	fmt.Println("Same result as strings.Join:", JoinStrings(words, ", ") == strings.Join(words, ", "))
+
+	// Сравнение с наивной склейкой через +=: go test -bench Join -benchmem
+
 	escapeExample := `Line1\nLine2`
 	fmt.Println("Raw string:", escapeExample)
 
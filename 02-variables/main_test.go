@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestJoinStrings(t *testing.T) {
+	tests := []struct {
+		parts []string
+		sep   string
+	}{
+		{nil, ", "},
+		{[]string{}, ", "},
+		{[]string{"alone"}, ", "},
+		{[]string{"a", "b", "c"}, ", "},
+		{[]string{"a", "", "c"}, "-"},
+		{[]string{"x", "y"}, ""},
+	}
+
+	for _, test := range tests {
+		expected := strings.Join(test.parts, test.sep)
+		if got := JoinStrings(test.parts, test.sep); got != expected {
+			t.Errorf("JoinStrings(%q, %q) = %q, expected %q", test.parts, test.sep, got, expected)
+		}
+	}
+}
+
+// Наивная склейка через +=: каждая итерация создает новую строку
+func joinStringsNaive(parts []string, sep string) string {
+	result := ""
+	for i, part := range parts {
+		if i > 0 {
+			result += sep
+		}
+		result += part
+	}
+	return result
+}
+
+var benchmarkWords = strings.Fields(strings.Repeat("go is fun ", 200))
+
+func BenchmarkJoinStrings(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		JoinStrings(benchmarkWords, ", ")
+	}
+}
+
+func BenchmarkJoinStringsNaive(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		joinStringsNaive(benchmarkWords, ", ")
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	const maxInt, minInt = math.MaxInt64, math.MinInt64
+	tests := []struct {
+		name     string
+		op       func() (int64, error)
+		expected int64
+		overflow bool
+	}{
+		{"MaxInt64 + 1", func() (int64, error) { return AddChecked(maxInt, 1) }, 0, true},
+		{"MinInt64 + -1", func() (int64, error) { return AddChecked(minInt, -1) }, 0, true},
+		{"MaxInt64-1 + 1", func() (int64, error) { return AddChecked(maxInt-1, 1) }, maxInt, false},
+		{"MaxInt64 + MinInt64", func() (int64, error) { return AddChecked(maxInt, minInt) }, -1, false},
+		{"MinInt64 + 0", func() (int64, error) { return AddChecked(minInt, 0) }, minInt, false},
+
+		{"MaxInt64 * 2", func() (int64, error) { return MulChecked(maxInt, 2) }, 0, true},
+		{"MinInt64 * -1", func() (int64, error) { return MulChecked(minInt, -1) }, 0, true},
+		{"-1 * MinInt64", func() (int64, error) { return MulChecked(-1, minInt) }, 0, true},
+		{"2^32 * 2^31", func() (int64, error) { return MulChecked(1<<32, 1<<31) }, 0, true},
+		{"MaxInt64 * -1", func() (int64, error) { return MulChecked(maxInt, -1) }, -maxInt, false},
+		{"MinInt64 * 1", func() (int64, error) { return MulChecked(minInt, 1) }, minInt, false},
+		{"MinInt64 * 0", func() (int64, error) { return MulChecked(minInt, 0) }, 0, false},
+		{"2^31 * 2^31", func() (int64, error) { return MulChecked(1<<31, 1<<31) }, 1 << 62, false},
+
+		{"-MinInt64", func() (int64, error) { return NegChecked(minInt) }, 0, true},
+		{"-MaxInt64", func() (int64, error) { return NegChecked(maxInt) }, -maxInt, false},
+		{"-0", func() (int64, error) { return NegChecked(0) }, 0, false},
+	}
+
+	for _, test := range tests {
+		got, err := test.op()
+		if test.overflow {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("%s = %d, %v, expected %v", test.name, got, err, ErrOverflow)
+			}
+			continue
+		}
+		if err != nil || got != test.expected {
+			t.Errorf("%s = %d, %v, expected %d, nil", test.name, got, err, test.expected)
+		}
+	}
+}
@@ -1,9 +1,236 @@
 package main
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
 )
 
+// Ключи карты в отсортированном порядке: порядок range по карте случаен,
+// а для тестов и логов нужен воспроизводимый вывод
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Обход карты в порядке возрастания ключей
+func RangeSorted[K cmp.Ordered, V any](m map[K]V, fn func(K, V)) {
+	for _, key := range SortedKeys(m) {
+		fn(key, m[key])
+	}
+}
+
+// Изменения между двумя версиями карты
+type MapChange[K comparable, V comparable] struct {
+	Added   map[K]V
+	Removed map[K]V
+	Changed map[K]ValueChange[V]
+}
+
+type ValueChange[V comparable] struct {
+	Old, New V
+}
+
+// Минимальный набор изменений, превращающий before в after. nil-карта считается пустой.
+func DiffMaps[K comparable, V comparable](before, after map[K]V) MapChange[K, V] {
+	change := MapChange[K, V]{
+		Added:   make(map[K]V),
+		Removed: make(map[K]V),
+		Changed: make(map[K]ValueChange[V]),
+	}
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		switch {
+		case !ok:
+			change.Removed[key] = oldValue
+		case newValue != oldValue:
+			change.Changed[key] = ValueChange[V]{Old: oldValue, New: newValue}
+		}
+	}
+	for key, newValue := range after {
+		if _, ok := before[key]; !ok {
+			change.Added[key] = newValue
+		}
+	}
+	return change
+}
+
+// Применяет изменения к копии m; исходная карта не меняется
+func ApplyChange[K comparable, V comparable](m map[K]V, change MapChange[K, V]) map[K]V {
+	result := make(map[K]V, len(m)+len(change.Added))
+	for key, value := range m {
+		result[key] = value
+	}
+	for key := range change.Removed {
+		delete(result, key)
+	}
+	for key, value := range change.Changed {
+		result[key] = value.New
+	}
+	for key, value := range change.Added {
+		result[key] = value
+	}
+	return result
+}
+
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeUpdated
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeUpdated:
+		return "updated"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Одно изменение ключа: для added заполнено только New, для removed — только Old
+type Change[K comparable, V comparable] struct {
+	Kind     ChangeKind
+	Key      K
+	Old, New V
+}
+
+// Отдает изменения между before и after по одному через канал из отдельной горутины.
+// Сначала идут удаления, затем обновления, затем добавления; порядок внутри группы случаен.
+// Горутина закрывает канал, когда изменения кончились или отменен ctx, поэтому
+// читатель, остановившийся на полпути, должен отменить ctx. Пока канал не закрыт,
+// before и after нельзя изменять.
+func SyncChanges[K comparable, V comparable](ctx context.Context, before, after map[K]V) <-chan Change[K, V] {
+	changes := make(chan Change[K, V])
+	go func() {
+		defer close(changes)
+		send := func(c Change[K, V]) bool {
+			select {
+			case changes <- c:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for key, old := range before {
+			if _, ok := after[key]; !ok && !send(Change[K, V]{Kind: ChangeRemoved, Key: key, Old: old}) {
+				return
+			}
+		}
+		for key, old := range before {
+			if value, ok := after[key]; ok && value != old && !send(Change[K, V]{Kind: ChangeUpdated, Key: key, Old: old, New: value}) {
+				return
+			}
+		}
+		for key, value := range after {
+			if _, ok := before[key]; !ok && !send(Change[K, V]{Kind: ChangeAdded, Key: key, New: value}) {
+				return
+			}
+		}
+	}()
+	return changes
+}
+
+// Превращает вложенные карты в плоскую карту с ключами через точку:
+// {"address": {"city": "X"}} -> {"address.city": "X"}.
+// Массивы и пустые вложенные карты остаются значениями как есть.
+func FlattenMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	flattenInto(result, "", m)
+	return result
+}
+
+func flattenInto(result map[string]interface{}, prefix string, m map[string]interface{}) {
+	for key, value := range m {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenInto(result, fullKey, nested)
+			continue
+		}
+		result[fullKey] = value
+	}
+}
+
+// Обратное преобразование: ключи с точками снова становятся вложенными картами.
+// Если один ключ является префиксом другого ("a" и "a.b"), возвращается ошибка.
+func UnflattenMap(m map[string]interface{}) (map[string]interface{}, error) {
+	// Сортировка делает результат и текст ошибок детерминированными
+	result := make(map[string]interface{})
+	for _, key := range SortedKeys(m) {
+		parts := strings.Split(key, ".")
+		current := result
+		for i, part := range parts[:len(parts)-1] {
+			next, exists := current[part]
+			if !exists {
+				child := make(map[string]interface{})
+				current[part] = child
+				current = child
+				continue
+			}
+			child, ok := next.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("key collision: %q and %q", strings.Join(parts[:i+1], "."), key)
+			}
+			current = child
+		}
+
+		last := parts[len(parts)-1]
+		if _, exists := current[last]; exists {
+			return nil, fmt.Errorf("key collision: %q is both a value and an object", key)
+		}
+		current[last] = m[key]
+	}
+	return result, nil
+}
+
+// Переопределяет значения конфигурации из переменных окружения вида PREFIX_KEY.
+// Ключ приводится к верхнему регистру, символы кроме букв и цифр заменяются на "_":
+// "db.max-conns" с префиксом "app" читается из APP_DB_MAX_CONNS.
+// Исходная карта не изменяется; если переменная не задана, значение остается прежним.
+func ApplyEnvOverrides(cfg map[string]string, prefix string) map[string]string {
+	result := make(map[string]string, len(cfg))
+	for key, value := range cfg {
+		if override, ok := os.LookupEnv(envName(prefix, key)); ok {
+			value = override
+		}
+		result[key] = value
+	}
+	return result
+}
+
+func envName(prefix, key string) string {
+	normalize := func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}
+	name := strings.Map(normalize, key)
+	if prefix == "" {
+		return name
+	}
+	return strings.Map(normalize, prefix) + "_" + name
+}
+
 func main() {
 	// Что такое карты?
 	// Карта (map) — это структура данных, которая хранит пары "ключ-значение".
@@ -63,6 +290,35 @@ func main() {
 		fmt.Printf("The price of %s is %.2f\n", product, price)
 	}
 
+	// Перебор в отсортированном порядке ключей (всегда одинаковый)
+	RangeSorted(productPrices, func(product string, price float64) {
+		fmt.Printf("Sorted: %s costs %.2f\n", product, price)
+	})
+
+	// Разница между двумя версиями карты и ее применение
+	newPrices := map[string]float64{
+		"apple":  1.09,
+		"banana": 1.29,
+		"kiwi":   0.59,
+	}
+	change := DiffMaps(productPrices, newPrices)
+	fmt.Println("Added:", change.Added, "Removed:", change.Removed, "Changed:", change.Changed)
+	fmt.Println("Patched prices:", ApplyChange(productPrices, change))
+
+	// Те же изменения потоком событий: клиент применяет их по одному
+	screen := maps.Clone(productPrices)
+	for c := range SyncChanges(context.Background(), productPrices, newPrices) {
+		fmt.Printf("Event: %s %s %v -> %v\n", c.Kind, c.Key, c.Old, c.New)
+		if c.Kind == ChangeRemoved {
+			delete(screen, c.Key)
+		} else {
+			screen[c.Key] = c.New
+		}
+	}
+	fmt.Println("Screen in sync:", maps.Equal(screen, newPrices))
+	_, open := <-SyncChanges(context.Background(), newPrices, newPrices)
+	fmt.Println("No changes, channel open:", open)
+
 	// Использование карты для подсчета частоты элементов
 	// Например, подсчет частоты появления символов в строке
 	text := "hello"
@@ -79,4 +335,30 @@ func main() {
 	} else {
 		fmt.Println("The map is not nil")
 	}
+
+	// Вложенные карты и ключи через точку (например, для конфигурации)
+	config := map[string]interface{}{
+		"server": map[string]interface{}{
+			"host": "localhost",
+			"port": 8080,
+		},
+		"features": []string{"auth", "cache"},
+	}
+	flat := FlattenMap(config)
+	fmt.Println("Flattened map:", flat)
+
+	nested, err := UnflattenMap(flat)
+	fmt.Println("Unflattened map:", nested, "Error:", err)
+
+	// "server" и "server.port" не могут существовать одновременно
+	_, err = UnflattenMap(map[string]interface{}{"server": "localhost", "server.port": 8080})
+	fmt.Println("Collision error:", err)
+
+	// Переопределение конфигурации переменными окружения
+	defaults := map[string]string{
+		"db.host":      "localhost",
+		"db.max-conns": "10",
+	}
+	os.Setenv("APP_DB_MAX_CONNS", "50")
+	fmt.Println("Config with env overrides:", ApplyEnvOverrides(defaults, "app"))
 }
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFlattenMap(t *testing.T) {
+	nested := map[string]interface{}{
+		"name": "app",
+		"db": map[string]interface{}{
+			"host": "localhost",
+			"pool": map[string]interface{}{"max": 10},
+		},
+		"tags":  []interface{}{"a", "b"},
+		"empty": map[string]interface{}{},
+	}
+	expected := map[string]interface{}{
+		"name":        "app",
+		"db.host":     "localhost",
+		"db.pool.max": 10,
+		"tags":        []interface{}{"a", "b"},
+		"empty":       map[string]interface{}{},
+	}
+
+	flat := FlattenMap(nested)
+	if !reflect.DeepEqual(flat, expected) {
+		t.Fatalf("FlattenMap() = %v, expected %v", flat, expected)
+	}
+
+	roundTrip, err := UnflattenMap(flat)
+	if err != nil {
+		t.Fatalf("UnflattenMap() error = %v", err)
+	}
+	if !reflect.DeepEqual(roundTrip, nested) {
+		t.Errorf("UnflattenMap(FlattenMap(m)) = %v, expected %v", roundTrip, nested)
+	}
+}
+
+func TestUnflattenMapCollision(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"a": 1, "a.b": 2},
+		{"a.b": 1, "a.b.c": 2},
+	}
+
+	for _, m := range tests {
+		if result, err := UnflattenMap(m); err == nil {
+			t.Errorf("UnflattenMap(%v) = %v, nil, expected a collision error", m, result)
+		}
+	}
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Setenv("APP_DB_MAX_CONNS", "50")
+	t.Setenv("APP_HOST", "example.com")
+	t.Setenv("HOST", "ignored-without-prefix")
+
+	cfg := map[string]string{
+		"host":         "localhost",
+		"port":         "8080",
+		"db.max-conns": "10",
+	}
+	result := ApplyEnvOverrides(cfg, "app")
+
+	expected := map[string]string{
+		"host":         "example.com",
+		"port":         "8080",
+		"db.max-conns": "50",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ApplyEnvOverrides() = %v, expected %v", result, expected)
+	}
+	if cfg["host"] != "localhost" {
+		t.Errorf("original config modified: host = %q", cfg["host"])
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		prefix, key, expected string
+	}{
+		{"app", "host", "APP_HOST"},
+		{"app", "db.max-conns", "APP_DB_MAX_CONNS"},
+		{"my-app", "log level", "MY_APP_LOG_LEVEL"},
+		{"", "db.host", "DB_HOST"},
+	}
+
+	for _, test := range tests {
+		if got := envName(test.prefix, test.key); got != test.expected {
+			t.Errorf("envName(%q, %q) = %q, expected %q", test.prefix, test.key, got, test.expected)
+		}
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]int{}
+	for _, key := range []string{"pear", "apple", "zucchini", "banana", "cherry"} {
+		m[key] = len(key)
+	}
+	expected := []string{"apple", "banana", "cherry", "pear", "zucchini"}
+	if got := SortedKeys(m); !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortedKeys() = %v, expected %v", got, expected)
+	}
+	if got := SortedKeys(map[int]bool{}); len(got) != 0 {
+		t.Errorf("SortedKeys(empty) = %v, expected no keys", got)
+	}
+}
+
+func TestRangeSorted(t *testing.T) {
+	m := map[int]string{30: "c", 10: "a", 20: "b", -5: "z"}
+	var keys []int
+	var values []string
+	RangeSorted(m, func(k int, v string) {
+		keys = append(keys, k)
+		values = append(values, v)
+	})
+	if !reflect.DeepEqual(keys, []int{-5, 10, 20, 30}) || !reflect.DeepEqual(values, []string{"z", "a", "b", "c"}) {
+		t.Errorf("RangeSorted() visited %v with values %v, expected [-5 10 20 30] with [z a b c]", keys, values)
+	}
+
+	calls := 0
+	RangeSorted(map[string]int(nil), func(string, int) { calls++ })
+	if calls != 0 {
+		t.Errorf("RangeSorted(nil) called fn %d times, expected 0", calls)
+	}
+}
+
+func TestDiffMaps(t *testing.T) {
+	before := map[string]int{"a": 1, "b": 2, "c": 3}
+	after := map[string]int{"a": 1, "b": 20, "d": 4}
+
+	change := DiffMaps(before, after)
+	expected := MapChange[string, int]{
+		Added:   map[string]int{"d": 4},
+		Removed: map[string]int{"c": 3},
+		Changed: map[string]ValueChange[int]{"b": {Old: 2, New: 20}},
+	}
+	if !reflect.DeepEqual(change, expected) {
+		t.Errorf("DiffMaps() = %+v, expected %+v", change, expected)
+	}
+
+	if got := ApplyChange(before, change); !reflect.DeepEqual(got, after) {
+		t.Errorf("ApplyChange(before, diff) = %v, expected %v", got, after)
+	}
+	if before["b"] != 2 || len(before) != 3 {
+		t.Errorf("ApplyChange modified the original map: %v", before)
+	}
+}
+
+func TestDiffMapsNil(t *testing.T) {
+	after := map[string]int{"x": 1}
+	change := DiffMaps(nil, after)
+	if !reflect.DeepEqual(change.Added, after) || len(change.Removed) != 0 || len(change.Changed) != 0 {
+		t.Errorf("DiffMaps(nil, %v) = %+v, expected everything added", after, change)
+	}
+	if got := ApplyChange(nil, change); !reflect.DeepEqual(got, after) {
+		t.Errorf("ApplyChange(nil, diff) = %v, expected %v", got, after)
+	}
+
+	change = DiffMaps(after, nil)
+	if got := ApplyChange(after, change); len(got) != 0 {
+		t.Errorf("ApplyChange(m, DiffMaps(m, nil)) = %v, expected empty map", got)
+	}
+}
+
+func TestSyncChanges(t *testing.T) {
+	before := map[string]int{"apple": 100, "pear": 80, "plum": 50, "kiwi": 30}
+	after := map[string]int{"apple": 120, "pear": 80, "kiwi": 30, "mango": 200, "lime": 40}
+
+	var changes []Change[string, int]
+	for c := range SyncChanges(context.Background(), before, after) {
+		changes = append(changes, c)
+	}
+
+	// Потоковые изменения совпадают с DiffMaps
+	diff := DiffMaps(before, after)
+	streamed := MapChange[string, int]{
+		Added:   map[string]int{},
+		Removed: map[string]int{},
+		Changed: map[string]ValueChange[int]{},
+	}
+	lastKind := ChangeRemoved
+	for _, c := range changes {
+		if kindOrder(c.Kind) < kindOrder(lastKind) {
+			t.Errorf("change %v came after %v changes, expected removed, updated, added", c, lastKind)
+		}
+		lastKind = c.Kind
+		switch c.Kind {
+		case ChangeAdded:
+			streamed.Added[c.Key] = c.New
+		case ChangeRemoved:
+			streamed.Removed[c.Key] = c.Old
+		case ChangeUpdated:
+			streamed.Changed[c.Key] = ValueChange[int]{Old: c.Old, New: c.New}
+		}
+	}
+	if len(changes) != 4 || !reflect.DeepEqual(streamed, diff) {
+		t.Errorf("streamed changes %v, expected %+v", changes, diff)
+	}
+}
+
+// Позиция вида изменения в потоке
+func kindOrder(k ChangeKind) int {
+	return map[ChangeKind]int{ChangeRemoved: 0, ChangeUpdated: 1, ChangeAdded: 2}[k]
+}
+
+func TestSyncChangesNoChanges(t *testing.T) {
+	same := map[string]int{"a": 1}
+	for _, test := range []struct{ before, after map[string]int }{{same, same}, {nil, nil}, {nil, map[string]int{}}} {
+		select {
+		case c, ok := <-SyncChanges(context.Background(), test.before, test.after):
+			if ok {
+				t.Errorf("SyncChanges(%v, %v) sent %v, expected closed channel", test.before, test.after, c)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("SyncChanges(%v, %v) channel is not closed", test.before, test.after)
+		}
+	}
+}
+
+func TestSyncChangesCancel(t *testing.T) {
+	after := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		after[i] = i
+	}
+	goroutines := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := SyncChanges(ctx, nil, after)
+	<-changes // Читатель берет одно изменение и уходит
+	cancel()
+
+	// После отмены горутина закрывает канал, не дойдя до конца изменений
+	received := 1
+	timeout := time.After(time.Second)
+	for open := true; open; {
+		select {
+		case _, open = <-changes:
+			if open {
+				received++
+			}
+		case <-timeout:
+			t.Fatal("channel was not closed after ctx was cancelled")
+		}
+	}
+	if received == len(after) {
+		t.Errorf("received all %d changes, expected the stream to stop on cancellation", received)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > goroutines {
+		t.Errorf("goroutines = %d after cancel, expected %d", n, goroutines)
+	}
+}
+
+func TestChangeKindString(t *testing.T) {
+	tests := map[ChangeKind]string{ChangeAdded: "added", ChangeRemoved: "removed", ChangeUpdated: "updated", 7: "ChangeKind(7)"}
+	for kind, expected := range tests {
+		if got := kind.String(); got != expected {
+			t.Errorf("ChangeKind(%d).String() = %q, expected %q", int(kind), got, expected)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 // Пример 1: Что такое слайсы
 func sliceExample() {
@@ -78,6 +81,266 @@ func sliceOperator() {
 	fmt.Println("Слайс с 3 элемента до конца:", subSlice3)
 }
 
+// Пример 7: Предварительное выделение памяти через make([]T, 0, n)
+// Обе функции возвращают одинаковый результат, но первая многократно
+// перевыделяет массив при росте, а вторая выделяет память один раз.
+func BuildAppendNoPrealloc(n int) []int {
+	var result []int
+	for i := 0; i < n; i++ {
+		result = append(result, i)
+	}
+	return result
+}
+
+func BuildAppendPrealloc(n int) []int {
+	result := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		result = append(result, i)
+	}
+	return result
+}
+
+func slicePrealloc() {
+	const n = 10000
+	fmt.Println("Результаты совпадают:", slices.Equal(BuildAppendNoPrealloc(n), BuildAppendPrealloc(n)))
+
+	// Считаем, сколько раз append перевыделил массив без предвыделения
+	var s []int
+	reallocs := 0
+	for i := 0; i < n; i++ {
+		oldCap := cap(s)
+		s = append(s, i)
+		if cap(s) != oldCap {
+			reallocs++
+		}
+	}
+	fmt.Println("Перевыделений без make([]int, 0, n):", reallocs)
+	// Сравнение скорости и аллокаций: go test -bench BuildAppend -benchmem
+}
+
+// Пример 8: Транспонирование двумерного слайса (строки становятся столбцами)
+// Для строк разной длины недостающие элементы заполняются нулевыми значениями,
+// а число строк результата равно длине самой длинной строки.
+func Transpose[T any](m [][]T) [][]T {
+	cols := 0
+	for _, row := range m {
+		cols = max(cols, len(row))
+	}
+
+	result := make([][]T, cols)
+	for j := range result {
+		result[j] = make([]T, len(m))
+		for i, row := range m {
+			if j < len(row) {
+				result[j][i] = row[j]
+			}
+		}
+	}
+	return result
+}
+
+func sliceTranspose() {
+	matrix := [][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	}
+	fmt.Println("Матрица 2x3:", matrix)
+	fmt.Println("Транспонированная 3x2:", Transpose(matrix))
+
+	// Строка превращается в столбец
+	fmt.Println("Строка в столбец:", Transpose([][]string{{"a", "b", "c"}}))
+
+	// Неровные строки дополняются нулями
+	fmt.Println("Неровная матрица:", Transpose([][]int{{1, 2, 3}, {4}}))
+}
+
+// Пример 9: Слайс, сообщающий об изменениях
+type ChangeType int
+
+const (
+	ChangeAppend ChangeType = iota
+	ChangeRemove
+)
+
+func (c ChangeType) String() string {
+	if c == ChangeAppend {
+		return "append"
+	}
+	return "remove"
+}
+
+// Событие изменения: тип, индекс и затронутое значение
+type ChangeEvent[T any] struct {
+	Type  ChangeType
+	Index int
+	Value T
+}
+
+type ObservableSlice[T any] struct {
+	items     []T
+	listeners []func(ChangeEvent[T])
+}
+
+// Регистрирует обработчик, который вызывается после каждого изменения
+func (s *ObservableSlice[T]) OnChange(fn func(ChangeEvent[T])) {
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *ObservableSlice[T]) Append(v T) {
+	s.items = append(s.items, v)
+	s.notify(ChangeEvent[T]{Type: ChangeAppend, Index: len(s.items) - 1, Value: v})
+}
+
+// Удаляет элемент по индексу; при выходе за границы возвращает ошибку и не вызывает обработчики
+func (s *ObservableSlice[T]) RemoveAt(i int) error {
+	if i < 0 || i >= len(s.items) {
+		return fmt.Errorf("index %d out of range [0, %d)", i, len(s.items))
+	}
+	v := s.items[i]
+	s.items = slices.Delete(s.items, i, i+1)
+	s.notify(ChangeEvent[T]{Type: ChangeRemove, Index: i, Value: v})
+	return nil
+}
+
+// Копия текущего содержимого
+func (s *ObservableSlice[T]) Items() []T {
+	return slices.Clone(s.items)
+}
+
+func (s *ObservableSlice[T]) notify(event ChangeEvent[T]) {
+	for _, fn := range s.listeners {
+		fn(event)
+	}
+}
+
+func sliceObservable() {
+	var todo ObservableSlice[string]
+	todo.OnChange(func(e ChangeEvent[string]) {
+		fmt.Printf("Изменение: %s, индекс %d, значение %q\n", e.Type, e.Index, e.Value)
+	})
+
+	todo.Append("купить молоко")
+	todo.Append("написать код")
+	if err := todo.RemoveAt(0); err != nil {
+		fmt.Println("Ошибка:", err)
+	}
+	if err := todo.RemoveAt(5); err != nil {
+		fmt.Println("Ошибка:", err)
+	}
+	fmt.Println("Слайс после изменений:", todo.Items())
+}
+
+// Пример 10: Циклический сдвиг слайса на месте
+// k > 0 сдвигает влево, k < 0 — вправо; |k| больше длины берется по модулю.
+// Три разворота вместо копии: дополнительная память не нужна.
+func Rotate[T any](s []T, k int) {
+	n := len(s)
+	if n == 0 {
+		return
+	}
+	k %= n
+	if k < 0 {
+		k += n // Сдвиг вправо на k — это сдвиг влево на n-k
+	}
+	if k == 0 {
+		return
+	}
+	reverse(s[:k])
+	reverse(s[k:])
+	reverse(s)
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func sliceRotate() {
+	days := []string{"пн", "вт", "ср", "чт", "пт"}
+	Rotate(days, 2)
+	fmt.Println("Сдвиг влево на 2:", days)
+	Rotate(days, -2)
+	fmt.Println("Сдвиг вправо на 2:", days)
+	Rotate(days, 7) // То же, что сдвиг на 2
+	fmt.Println("Сдвиг влево на 7:", days)
+	Rotate(days, -10) // Кратно длине — ничего не меняется
+	fmt.Println("Сдвиг вправо на 10:", days)
+}
+
+// Пример 11: Слияние нескольких слайсов по кругу (round-robin)
+// Берет по одному элементу из каждого слайса по очереди; закончившиеся слайсы
+// пропускаются, поэтому хвост самого длинного слайса идет в конце подряд.
+func Interleave[T any](slices ...[]T) []T {
+	total, longest := 0, 0
+	for _, s := range slices {
+		total += len(s)
+		longest = max(longest, len(s))
+	}
+
+	result := make([]T, 0, total)
+	for i := 0; i < longest; i++ {
+		for _, s := range slices {
+			if i < len(s) {
+				result = append(result, s[i])
+			}
+		}
+	}
+	return result
+}
+
+func sliceInterleave() {
+	// Честная очередь задач от разных клиентов
+	alice := []string{"a1", "a2", "a3", "a4"}
+	bob := []string{"b1"}
+	carol := []string{"c1", "c2"}
+	fmt.Println("По кругу:", Interleave(alice, bob, nil, carol))
+}
+
+// Пример 12: Неизменяемый слайс
+// Freeze копирует данные, а наружу отдаются только копии элементов,
+// поэтому Frozen можно без блокировок читать из многих горутин.
+// Заморозка поверхностная: данные по указателям в элементах остаются изменяемыми.
+type Frozen[T any] struct {
+	items []T
+}
+
+func Freeze[T any](s []T) Frozen[T] {
+	return Frozen[T]{items: append([]T(nil), s...)}
+}
+
+func (f Frozen[T]) Get(i int) (T, error) {
+	if i < 0 || i >= len(f.items) {
+		var zero T
+		return zero, fmt.Errorf("index %d out of range [0, %d)", i, len(f.items))
+	}
+	return f.items[i], nil
+}
+
+func (f Frozen[T]) Len() int {
+	return len(f.items)
+}
+
+// Возвращает копию: изменения в ней не влияют на Frozen
+func (f Frozen[T]) ToSlice() []T {
+	return append([]T(nil), f.items...)
+}
+
+func sliceFrozen() {
+	source := []string{"read", "write"}
+	permissions := Freeze(source)
+
+	source[0] = "admin" // Исходный слайс меняется, замороженный — нет
+	copied := permissions.ToSlice()
+	copied[1] = "delete" // Копия тоже не связана с Frozen
+
+	first, _ := permissions.Get(0)
+	fmt.Println("Замороженный слайс:", permissions.ToSlice(), "первый:", first, "длина:", permissions.Len())
+	if _, err := permissions.Get(5); err != nil {
+		fmt.Println("Ошибка:", err)
+	}
+}
+
 func main() {
 	// Пример 1: Что такое слайсы
 	sliceExample()
@@ -96,4 +359,22 @@ func main() {
 
 	// Пример 6: Slice оператор
 	sliceOperator()
+
+	// Пример 7: Предварительное выделение памяти
+	slicePrealloc()
+
+	// Пример 8: Транспонирование двумерного слайса
+	sliceTranspose()
+
+	// Пример 9: Слайс, сообщающий об изменениях
+	sliceObservable()
+
+	// Пример 10: Циклический сдвиг слайса
+	sliceRotate()
+
+	// Пример 11: Слияние слайсов по кругу
+	sliceInterleave()
+
+	// Пример 12: Неизменяемый слайс
+	sliceFrozen()
 }
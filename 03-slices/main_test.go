@@ -0,0 +1,214 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestBuildAppend(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 1000} {
+		noPrealloc, prealloc := BuildAppendNoPrealloc(n), BuildAppendPrealloc(n)
+		if !slices.Equal(noPrealloc, prealloc) {
+			t.Errorf("BuildAppendNoPrealloc(%d) = %v, BuildAppendPrealloc(%d) = %v, expected equal", n, noPrealloc, n, prealloc)
+		}
+		if len(prealloc) != n || cap(prealloc) != n {
+			t.Errorf("BuildAppendPrealloc(%d): len = %d, cap = %d, expected %d", n, len(prealloc), cap(prealloc), n)
+		}
+	}
+}
+
+const benchmarkSize = 10000
+
+func BenchmarkBuildAppendNoPrealloc(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		BuildAppendNoPrealloc(benchmarkSize)
+	}
+}
+
+func BenchmarkBuildAppendPrealloc(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		BuildAppendPrealloc(benchmarkSize)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        [][]int
+		expected [][]int
+	}{
+		{"2x3", [][]int{{1, 2, 3}, {4, 5, 6}}, [][]int{{1, 4}, {2, 5}, {3, 6}}},
+		{"single row", [][]int{{1, 2, 3}}, [][]int{{1}, {2}, {3}}},
+		{"single column", [][]int{{1}, {2}}, [][]int{{1, 2}}},
+		{"ragged", [][]int{{1, 2, 3}, {4}}, [][]int{{1, 4}, {2, 0}, {3, 0}}},
+		{"empty", [][]int{}, [][]int{}},
+		{"nil", nil, [][]int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Transpose(test.m)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Transpose(%v) = %v, expected %v", test.m, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestObservableSlice(t *testing.T) {
+	var s ObservableSlice[string]
+	var events []ChangeEvent[string]
+	s.OnChange(func(e ChangeEvent[string]) { events = append(events, e) })
+	secondCalls := 0
+	s.OnChange(func(ChangeEvent[string]) { secondCalls++ })
+
+	s.Append("a")
+	s.Append("b")
+	s.Append("c")
+	if err := s.RemoveAt(1); err != nil {
+		t.Fatalf("RemoveAt(1) = %v, expected nil", err)
+	}
+
+	expected := []ChangeEvent[string]{
+		{ChangeAppend, 0, "a"},
+		{ChangeAppend, 1, "b"},
+		{ChangeAppend, 2, "c"},
+		{ChangeRemove, 1, "b"},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("events = %v, expected %v", events, expected)
+	}
+	if secondCalls != len(expected) {
+		t.Errorf("second listener called %d times, expected %d", secondCalls, len(expected))
+	}
+	if got := s.Items(); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("Items() = %v, expected [a c]", got)
+	}
+}
+
+func TestObservableSliceRemoveOutOfRange(t *testing.T) {
+	var s ObservableSlice[int]
+	s.Append(1)
+	fired := 0
+	s.OnChange(func(ChangeEvent[int]) { fired++ })
+
+	for _, i := range []int{-1, 1, 10} {
+		if err := s.RemoveAt(i); err == nil {
+			t.Errorf("RemoveAt(%d) = nil, expected an error", i)
+		}
+	}
+	if fired != 0 {
+		t.Errorf("listener fired %d times on failed RemoveAt, expected 0", fired)
+	}
+	if got := s.Items(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Items() = %v, expected [1]", got)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		k        int
+		expected []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{1, []int{2, 3, 4, 5, 1}},
+		{2, []int{3, 4, 5, 1, 2}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{-2, []int{4, 5, 1, 2, 3}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{-10, []int{1, 2, 3, 4, 5}},
+		{7, []int{3, 4, 5, 1, 2}},
+		{-7, []int{4, 5, 1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		s := []int{1, 2, 3, 4, 5}
+		Rotate(s, test.k)
+		if !reflect.DeepEqual(s, test.expected) {
+			t.Errorf("Rotate([1 2 3 4 5], %d) = %v, expected %v", test.k, s, test.expected)
+		}
+	}
+}
+
+func TestRotateEmpty(t *testing.T) {
+	for _, k := range []int{0, 1, -3} {
+		var s []string
+		Rotate(s, k) // Не паникует на делении по модулю нуля
+		empty := []string{}
+		Rotate(empty, k)
+		if s != nil || len(empty) != 0 {
+			t.Errorf("Rotate on empty slice with k=%d changed it", k)
+		}
+	}
+
+	single := []string{"a"}
+	Rotate(single, 3)
+	if !reflect.DeepEqual(single, []string{"a"}) {
+		t.Errorf("Rotate([a], 3) = %v, expected [a]", single)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    [][]string
+		expected []string
+	}{
+		{"different lengths",
+			[][]string{{"a1", "a2", "a3", "a4"}, {"b1"}, {"c1", "c2"}},
+			[]string{"a1", "b1", "c1", "a2", "c2", "a3", "a4"}},
+		{"empty slices skipped",
+			[][]string{nil, {"a1", "a2"}, {}, {"b1", "b2"}},
+			[]string{"a1", "b1", "a2", "b2"}},
+		{"single slice", [][]string{{"a1", "a2"}}, []string{"a1", "a2"}},
+		{"all empty", [][]string{nil, {}}, []string{}},
+		{"no slices", nil, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Interleave(test.input...); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Interleave(%q) = %q, expected %q", test.input, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestFrozen(t *testing.T) {
+	source := []string{"read", "write"}
+	frozen := Freeze(source)
+
+	// Изменения исходного слайса и копий не видны во Frozen
+	source[0] = "admin"
+	copied := frozen.ToSlice()
+	copied[1] = "delete"
+
+	if got := frozen.ToSlice(); !reflect.DeepEqual(got, []string{"read", "write"}) {
+		t.Errorf("ToSlice() = %q, expected [read write]", got)
+	}
+	if frozen.Len() != 2 {
+		t.Errorf("Len() = %d, expected 2", frozen.Len())
+	}
+	for i, expected := range []string{"read", "write"} {
+		if v, err := frozen.Get(i); err != nil || v != expected {
+			t.Errorf("Get(%d) = %q, %v, expected %q, nil", i, v, err, expected)
+		}
+	}
+}
+
+func TestFrozenGetOutOfRange(t *testing.T) {
+	frozen := Freeze([]int{1, 2, 3})
+	for _, i := range []int{-1, 3, 100} {
+		if v, err := frozen.Get(i); err == nil || v != 0 {
+			t.Errorf("Get(%d) = %d, %v, expected 0 and error", i, v, err)
+		}
+	}
+
+	var empty Frozen[int]
+	if _, err := empty.Get(0); err == nil || empty.Len() != 0 || len(empty.ToSlice()) != 0 {
+		t.Error("zero Frozen is not an empty slice")
+	}
+}
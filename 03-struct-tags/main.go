@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Структуры с тегами валидации: правила перечисляются через запятую
+type Address struct {
+	City    string `validate:"required"`
+	ZipCode int    `validate:"min=10000,max=99999"`
+}
+
+type Person struct {
+	FirstName string `validate:"required"`
+	LastName  string `validate:"required,max=50"`
+	Age       int    `validate:"min=0,max=150"`
+	Address   Address
+	nickname  string `validate:"required"` // Неэкспортируемые поля пропускаются
+}
+
+// Нарушение правила для конкретного поля
+type FieldError struct {
+	Field string
+	Rule  string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s violates rule %q", e.Field, e.Rule)
+}
+
+// Все нарушения, найденные Validate. Каждая запись относится к одному полю:
+// это *FieldError для нарушенного правила или ошибка в теге поля.
+type MultiError struct {
+	errs []error
+}
+
+func (m *MultiError) Add(err error) {
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+}
+
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.errs), strings.Join(messages, "; "))
+}
+
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
+
+// Нарушения правил, сгруппированные по имени поля ("Address.City")
+func (m *MultiError) FieldErrors() map[string][]*FieldError {
+	result := make(map[string][]*FieldError)
+	for _, err := range m.errs {
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			result[fieldErr.Field] = append(result[fieldErr.Field], fieldErr)
+		}
+	}
+	return result
+}
+
+// Проверяет поля структуры по тегам `validate:"..."`.
+// Поддерживаются правила required, min=N и max=N (для строк и слайсов — по длине).
+// Вложенные структуры и ненулевые указатели на структуры проверяются рекурсивно;
+// nil-указатель пропускается, если у поля нет правила required.
+// Все нарушения возвращаются в *MultiError.
+func Validate(v any) error {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return errors.New("validate: nil pointer")
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: expected struct, got %s", val.Kind())
+	}
+
+	var errs MultiError
+	validateStruct(val, "", &errs, make(map[uintptr]bool))
+	return errs.ErrorOrNil()
+}
+
+// visiting содержит указатели на текущем пути рекурсии, чтобы цикл
+// из указателей (например, a.Next = a) не приводил к бесконечной рекурсии
+func validateStruct(val reflect.Value, prefix string, errs *MultiError, visiting map[uintptr]bool) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := prefix + field.Name
+		fieldVal := val.Field(i)
+
+		if tag := field.Tag.Get("validate"); tag != "" {
+			for _, rule := range strings.Split(tag, ",") {
+				rule = strings.TrimSpace(rule)
+				err := checkRule(fieldVal, rule)
+				switch {
+				case errors.Is(err, errRuleViolated):
+					errs.Add(&FieldError{Field: name, Rule: rule})
+				case err != nil: // Ошибка в самом теге
+					errs.Add(fmt.Errorf("field %s: %w", name, err))
+				}
+			}
+		}
+
+		switch {
+		case fieldVal.Kind() == reflect.Struct:
+			validateStruct(fieldVal, name+".", errs, visiting)
+		case fieldVal.Kind() == reflect.Pointer && !fieldVal.IsNil() && fieldVal.Elem().Kind() == reflect.Struct:
+			ptr := fieldVal.Pointer()
+			if visiting[ptr] {
+				continue
+			}
+			visiting[ptr] = true
+			validateStruct(fieldVal.Elem(), name+".", errs, visiting)
+			delete(visiting, ptr)
+		}
+	}
+}
+
+var errRuleViolated = errors.New("rule violated")
+
+func checkRule(v reflect.Value, rule string) error {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if v.IsZero() {
+			return errRuleViolated
+		}
+	case "min", "max":
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid rule %q: %w", rule, err)
+		}
+		n, ok := measure(v)
+		if !ok {
+			return fmt.Errorf("rule %q is not supported for %s", rule, v.Kind())
+		}
+		if (name == "min" && n < limit) || (name == "max" && n > limit) {
+			return errRuleViolated
+		}
+	default:
+		return fmt.Errorf("unknown rule %q", rule)
+	}
+	return nil
+}
+
+// Числовое значение для сравнения с min/max
+func measure(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), true
+	default:
+		return 0, false
+	}
+}
+
+func main() {
+	valid := Person{
+		FirstName: "John",
+		LastName:  "Doe",
+		Age:       30,
+		Address:   Address{City: "Springfield", ZipCode: 12345},
+	}
+	fmt.Println("Valid person:", Validate(valid))
+
+	invalid := Person{
+		LastName: "Doe",
+		Age:      200,
+		Address:  Address{ZipCode: 123},
+	}
+	err := Validate(&invalid)
+	fmt.Println("Invalid person:", err)
+
+	var multiErr *MultiError
+	if errors.As(err, &multiErr) {
+		for field, fieldErrs := range multiErr.FieldErrors() {
+			fmt.Println("Field", field, "violations:", len(fieldErrs))
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Собирает нарушения правил из ошибки Validate в виде "поле rule"
+func violations(err error) []string {
+	var result []string
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		return result
+	}
+	for field, fieldErrs := range multiErr.FieldErrors() {
+		for _, fieldErr := range fieldErrs {
+			result = append(result, field+" "+fieldErr.Rule)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestValidate(t *testing.T) {
+	valid := Person{
+		FirstName: "John",
+		LastName:  "Doe",
+		Age:       30,
+		Address:   Address{City: "Springfield", ZipCode: 12345},
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(p *Person)
+		expected []string
+	}{
+		{"valid", func(p *Person) {}, nil},
+		{"missing required", func(p *Person) { p.FirstName = "" }, []string{"FirstName required"}},
+		{"below min", func(p *Person) { p.Age = -1 }, []string{"Age min=0"}},
+		{"above max", func(p *Person) { p.Age = 151 }, []string{"Age max=150"}},
+		{"string length", func(p *Person) { p.LastName = string(make([]byte, 51)) }, []string{"LastName max=50"}},
+		{"nested struct", func(p *Person) { p.Address = Address{ZipCode: 123} }, []string{"Address.City required", "Address.ZipCode min=10000"}},
+		{"unexported skipped", func(p *Person) { p.nickname = "" }, nil},
+		{"several fields", func(p *Person) { p.FirstName, p.Age = "", 200 }, []string{"Age max=150", "FirstName required"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := valid
+			test.modify(&p)
+			err := Validate(&p)
+			if test.expected == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, expected nil", err)
+				}
+				return
+			}
+			if got := violations(err); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Validate() violations = %v, expected %v (error: %v)", got, test.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateInvalidInput(t *testing.T) {
+	var nilPerson *Person
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"nil pointer", nilPerson},
+		{"not a struct", 42},
+		{"unknown rule", struct {
+			Name string `validate:"email"`
+		}{"x"}},
+		{"bad limit", struct {
+			Age int `validate:"min=abc"`
+		}{1}},
+	}
+
+	for _, test := range tests {
+		if err := Validate(test.value); err == nil {
+			t.Errorf("Validate(%s) = nil, expected an error", test.name)
+		}
+	}
+}
+
+func TestValidateMultiError(t *testing.T) {
+	err := Validate(Person{LastName: "Doe", Age: 200, Address: Address{City: "Springfield", ZipCode: 1}})
+
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("Validate() = %T, expected *MultiError", err)
+	}
+	fields := multiErr.FieldErrors()
+	expected := map[string][]string{
+		"FirstName":       {"required"},
+		"Age":             {"max=150"},
+		"Address.ZipCode": {"min=10000"},
+	}
+	if len(fields) != len(expected) {
+		t.Errorf("FieldErrors() has %d fields, expected %d: %v", len(fields), len(expected), err)
+	}
+	for field, rules := range expected {
+		var got []string
+		for _, fieldErr := range fields[field] {
+			got = append(got, fieldErr.Rule)
+		}
+		if !reflect.DeepEqual(got, rules) {
+			t.Errorf("FieldErrors()[%q] rules = %v, expected %v", field, got, rules)
+		}
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Error("errors.As does not find *FieldError inside MultiError")
+	}
+}
+
+func TestValidatePointerFields(t *testing.T) {
+	type Node struct {
+		Name string `validate:"required"`
+		Next *Node
+	}
+	type Order struct {
+		ID       int      `validate:"min=1"`
+		Shipping *Address `validate:"required"`
+		Billing  *Address
+	}
+
+	cycle := &Node{Name: "a"}
+	cycle.Next = &Node{Next: cycle} // Второй узел без имени замыкает цикл
+
+	tests := []struct {
+		name     string
+		value    any
+		expected []string
+	}{
+		{"valid pointers", Order{ID: 1, Shipping: &Address{City: "Springfield", ZipCode: 12345}}, nil},
+		{"pointer to invalid struct", Order{ID: 1, Shipping: &Address{ZipCode: 12345}}, []string{"Shipping.City required"}},
+		{"nil optional pointer", Order{ID: 1, Shipping: &Address{City: "Springfield", ZipCode: 12345}, Billing: nil}, nil},
+		{"nil required pointer", Order{ID: 1}, []string{"Shipping required"}},
+		{"both pointers", Order{ID: 1, Shipping: &Address{City: "A", ZipCode: 1}, Billing: &Address{ZipCode: 12345}},
+			[]string{"Billing.City required", "Shipping.ZipCode min=10000"}},
+		{"pointer cycle", cycle, []string{"Next.Name required"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.value)
+			if test.expected == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, expected nil", err)
+				}
+				return
+			}
+			if got := violations(err); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Validate() violations = %v, expected %v (error: %v)", got, test.expected, err)
+			}
+		})
+	}
+}
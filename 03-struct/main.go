@@ -1,6 +1,12 @@
 package main
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
 
 // Определение структуры
 type Person struct {
@@ -56,6 +62,228 @@ func NewEmployee(firstName, lastName string, age int, position string, salary in
 	}
 }
 
+// Builder для Employee: поля задаются по имени цепочкой вызовов,
+// а Build проверяет обязательные поля
+type EmployeeBuilder struct {
+	employee Employee
+}
+
+func NewEmployeeBuilder() *EmployeeBuilder {
+	return &EmployeeBuilder{}
+}
+
+func (b *EmployeeBuilder) WithName(firstName, lastName string) *EmployeeBuilder {
+	b.employee.FirstName = firstName
+	b.employee.LastName = lastName
+	return b
+}
+
+func (b *EmployeeBuilder) WithAge(age int) *EmployeeBuilder {
+	b.employee.Age = age
+	return b
+}
+
+func (b *EmployeeBuilder) WithPosition(position string) *EmployeeBuilder {
+	b.employee.Position = position
+	return b
+}
+
+func (b *EmployeeBuilder) WithSalary(salary int) *EmployeeBuilder {
+	b.employee.Salary = salary
+	return b
+}
+
+func (b *EmployeeBuilder) Build() (*Employee, error) {
+	if b.employee.FirstName == "" || b.employee.LastName == "" {
+		return nil, errors.New("employee name is required")
+	}
+	if b.employee.Position == "" {
+		return nil, errors.New("employee position is required")
+	}
+	if b.employee.Age < 0 {
+		return nil, errors.New("employee age cannot be negative")
+	}
+	if b.employee.Salary < 0 {
+		return nil, errors.New("employee salary cannot be negative")
+	}
+	e := b.employee
+	return &e, nil
+}
+
+// Преобразование структуры в map по тем же правилам, что и encoding/json:
+// имена берутся из тегов json (если есть), поля встроенных структур поднимаются
+// на верхний уровень, указатели разыменовываются, неэкспортируемые поля пропускаются.
+// При совпадении имен побеждает менее вложенное поле; на одной глубине — поле с тегом,
+// а если так выбрать нельзя, имя пропускается целиком.
+func StructToMap(v any) (map[string]interface{}, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil, errors.New("struct to map: nil pointer")
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("struct to map: expected struct, got %s", val.Kind())
+	}
+
+	result := make(map[string]interface{})
+	for _, field := range mapFields(val.Type()) {
+		fieldVal, ok := fieldByIndex(val, field.index)
+		if !ok { // Поле nil-указателя на встроенную структуру
+			continue
+		}
+		if field.omitEmpty && isEmptyValue(fieldVal) {
+			continue
+		}
+		for fieldVal.Kind() == reflect.Pointer && !fieldVal.IsNil() {
+			fieldVal = fieldVal.Elem()
+		}
+		if fieldVal.Kind() == reflect.Pointer { // nil-указатель
+			result[field.name] = nil
+			continue
+		}
+		result[field.name] = fieldVal.Interface()
+	}
+	return result, nil
+}
+
+// Поле итогового map: index — путь до него через встроенные структуры
+type mapField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+}
+
+// Собирает поля так же, как encoding/json: обход встроенных структур в ширину,
+// затем для каждого имени остается только доминирующее поле.
+func mapFields(typ reflect.Type) []mapField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var fields []mapField
+	next := []embedded{{typ: typ}}
+	visited := map[reflect.Type]bool{}
+	var count, nextCount map[reflect.Type]int
+
+	for len(next) > 0 {
+		current := next
+		next = nil
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				fieldType := sf.Type
+				if fieldType.Kind() == reflect.Pointer && fieldType.Name() == "" {
+					fieldType = fieldType.Elem()
+				}
+				if sf.Anonymous {
+					// Поля неэкспортируемой встроенной структуры все равно поднимаются наверх
+					if !sf.IsExported() && fieldType.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				name, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+				if name == "-" && opts == "" {
+					continue
+				}
+				index := append(append([]int(nil), e.index...), i)
+
+				if name != "" || !sf.Anonymous || fieldType.Kind() != reflect.Struct {
+					field := mapField{
+						name:      name,
+						index:     index,
+						tagged:    name != "",
+						omitEmpty: strings.Contains(opts, "omitempty"),
+					}
+					if field.name == "" {
+						field.name = sf.Name
+					}
+					fields = append(fields, field)
+					// Одна и та же структура встроена дважды на одном уровне:
+					// дубликат гарантирует, что ее поля взаимно уничтожатся
+					if count[e.typ] > 1 {
+						fields = append(fields, field)
+					}
+					continue
+				}
+
+				nextCount[fieldType]++
+				if nextCount[fieldType] == 1 {
+					next = append(next, embedded{typ: fieldType, index: index})
+				}
+			}
+		}
+	}
+
+	// По имени, затем по глубине, затем поля с тегом раньше полей без тега
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+
+	var dominant []mapField
+	for start := 0; start < len(fields); {
+		end := start + 1
+		for end < len(fields) && fields[end].name == fields[start].name {
+			end++
+		}
+		first := fields[start]
+		// Второе поле на той же глубине с тем же статусом тега — неоднозначность
+		if end-start == 1 || len(fields[start+1].index) > len(first.index) || fields[start+1].tagged != first.tagged {
+			dominant = append(dominant, first)
+		}
+		start = end
+	}
+	return dominant
+}
+
+// Значение поля по пути; false, если путь проходит через nil-указатель
+func fieldByIndex(val reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && val.Kind() == reflect.Pointer {
+			if val.IsNil() {
+				return reflect.Value{}, false
+			}
+			val = val.Elem()
+		}
+		val = val.Field(x)
+	}
+	return val, true
+}
+
+// Пустое значение в смысле omitempty из encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
 func main() {
 	// 1. Инициализация структуры с именованными полями
 	p1 := Person{
@@ -133,6 +361,32 @@ func main() {
 	// 10. Инициализация Employee с помощью конструктора
 	e2 := NewEmployee("Eve", "Green", 32, "Data Scientist", 85000)
 	fmt.Println("Employee from constructor:", e2.FullName(), "Position:", e2.Position, "Salary:", e2.Salary)
+
+	// 11. Инициализация Employee с помощью builder
+	e3, err := NewEmployeeBuilder().
+		WithName("Frank", "Black").
+		WithAge(45).
+		WithPosition("Engineering Manager").
+		WithSalary(120000).
+		Build()
+	if err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Println("Employee from builder:", e3.FullName(), "Position:", e3.Position, "Salary:", e3.Salary)
+	}
+
+	// Builder без обязательного поля возвращает ошибку
+	if _, err := NewEmployeeBuilder().WithPosition("Intern").Build(); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// 12. Преобразование структуры в map: поля встроенной Person поднимаются наверх
+	employeeMap, err := StructToMap(e2)
+	if err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Println("Employee as map:", employeeMap)
+	}
 }
 
 // Функция, принимающая структуру в качестве аргумента
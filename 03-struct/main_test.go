@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEmployeeBuilder(t *testing.T) {
+	e, err := NewEmployeeBuilder().
+		WithName("Jane", "Doe").
+		WithAge(28).
+		WithPosition("Engineer").
+		WithSalary(5000).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	expected := NewEmployee("Jane", "Doe", 28, "Engineer", 5000)
+	if !reflect.DeepEqual(e, expected) {
+		t.Errorf("Build() = %+v, expected %+v", e, expected)
+	}
+}
+
+func TestEmployeeBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *EmployeeBuilder
+	}{
+		{"missing name", NewEmployeeBuilder().WithPosition("Intern")},
+		{"missing last name", NewEmployeeBuilder().WithName("Jane", "").WithPosition("Intern")},
+		{"missing position", NewEmployeeBuilder().WithName("Jane", "Doe")},
+		{"negative age", NewEmployeeBuilder().WithName("Jane", "Doe").WithPosition("Intern").WithAge(-1)},
+		{"negative salary", NewEmployeeBuilder().WithName("Jane", "Doe").WithPosition("Intern").WithSalary(-1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if e, err := test.builder.Build(); err == nil {
+				t.Errorf("Build() = %+v, nil, expected an error", e)
+			}
+		})
+	}
+}
+
+func TestEmployeeBuilderReturnsCopy(t *testing.T) {
+	b := NewEmployeeBuilder().WithName("Jane", "Doe").WithPosition("Engineer")
+	first, _ := b.Build()
+	second, _ := b.WithPosition("Manager").Build()
+	if first.Position != "Engineer" || second.Position != "Manager" {
+		t.Errorf("positions = %q, %q, expected \"Engineer\", \"Manager\"", first.Position, second.Position)
+	}
+}
+
+func TestStructToMap(t *testing.T) {
+	e := NewEmployee("Jane", "Doe", 28, "Engineer", 5000)
+	got, err := StructToMap(e)
+	if err != nil {
+		t.Fatalf("StructToMap() error = %v", err)
+	}
+	expected := map[string]interface{}{
+		"FirstName": "Jane",
+		"LastName":  "Doe",
+		"Age":       28,
+		"Position":  "Engineer",
+		"Salary":    5000,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("StructToMap(Employee) = %v, expected %v", got, expected)
+	}
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type Document struct {
+	*Audit
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Secret   string  `json:"-"`
+	Rating   *int    `json:"rating"`
+	Editor   *string `json:"editor"`
+	draft    bool
+}
+
+func TestStructToMapTagsAndPointers(t *testing.T) {
+	rating := 5
+	doc := Document{
+		Audit:  &Audit{CreatedBy: "alice"},
+		Title:  "Report",
+		Secret: "hidden",
+		Rating: &rating,
+		draft:  true,
+	}
+	got, err := StructToMap(&doc)
+	if err != nil {
+		t.Fatalf("StructToMap() error = %v", err)
+	}
+	expected := map[string]interface{}{
+		"created_by": "alice",
+		"title":      "Report",
+		"rating":     5,
+		"editor":     nil,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("StructToMap(Document) = %v, expected %v", got, expected)
+	}
+
+	// nil-указатель на встроенную структуру пропускается
+	doc.Audit = nil
+	got, err = StructToMap(doc)
+	if err != nil {
+		t.Fatalf("StructToMap() with nil embedded pointer error = %v", err)
+	}
+	delete(expected, "created_by")
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("StructToMap(Document with nil Audit) = %v, expected %v", got, expected)
+	}
+}
+
+func TestStructToMapErrors(t *testing.T) {
+	var nilEmployee *Employee
+	for _, v := range []any{nilEmployee, 42, "text"} {
+		if _, err := StructToMap(v); err == nil {
+			t.Errorf("StructToMap(%#v) = nil error, expected an error", v)
+		}
+	}
+}
+
+type outerName struct {
+	Name string
+	innerName
+}
+
+type innerName struct {
+	Name  string
+	Inner string
+}
+
+type nameA struct{ Name, A string }
+type nameB struct{ Name, B string }
+
+type taggedName struct {
+	Title string `json:"Name"`
+}
+
+type deepName struct {
+	innerName // Name на глубине 2 проигрывает полю Name на глубине 1
+}
+
+type withTags struct {
+	Score  int       `json:"score,omitempty"`
+	Tags   []string  `json:"tags,omitempty"`
+	Nested innerName `json:"nested"`
+}
+
+func TestStructToMapDominance(t *testing.T) {
+	outer := outerName{Name: "outer", innerName: innerName{Name: "inner", Inner: "x"}}
+	got, err := StructToMap(outer)
+	if err != nil {
+		t.Fatalf("StructToMap() error = %v", err)
+	}
+	if got["Name"] != "outer" {
+		t.Errorf("StructToMap(outerName)[Name] = %v, expected outer", got["Name"])
+	}
+
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"shallower field wins", outer},
+		{"shallower field wins, embedded first", struct {
+			innerName
+			Name string
+		}{innerName{Name: "inner"}, "outer"}},
+		{"same depth conflict drops the name", struct {
+			nameA
+			nameB
+		}{nameA{"a", "1"}, nameB{"b", "2"}}},
+		{"tag wins at the same depth", struct {
+			nameA
+			taggedName
+		}{nameA{"a", "1"}, taggedName{"tagged"}}},
+		{"deeper conflict loses to shallower", struct {
+			Name string
+			deepName
+			nameA
+		}{"top", deepName{innerName{Name: "deep"}}, nameA{Name: "a"}}},
+		{"embedded pointer", struct {
+			*innerName
+			Extra int
+		}{&innerName{Name: "p", Inner: "q"}, 1}},
+		{"nil embedded pointer", struct {
+			*innerName
+			Extra int
+		}{nil, 1}},
+		{"omitempty and tagged struct", withTags{Tags: []string{}, Nested: innerName{Name: "n"}}},
+		{"omitempty with values", withTags{Score: 3, Tags: []string{"go"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := StructToMap(test.value)
+			if err != nil {
+				t.Fatalf("StructToMap() error = %v", err)
+			}
+			if got, expected := jsonObject(t, m), jsonObject(t, test.value); !reflect.DeepEqual(got, expected) {
+				t.Errorf("StructToMap() = %v, json.Marshal gives %v", got, expected)
+			}
+		})
+	}
+}
+
+// Результат json.Marshal, разобранный обратно в map, для сравнения без учета порядка ключей
+func jsonObject(t *testing.T, v any) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) error = %v", v, err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+// Пример накопления нескольких ошибок в одной (например, при валидации)
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNoFallbacks = errors.New("no functions to try")
+	ErrEmptyName   = errors.New("name is empty")
+	ErrInvalidAge  = errors.New("age is out of range")
+	ErrInvalidMail = errors.New("email is invalid")
+)
+
+// Ошибка, содержащая список ошибок
+type MultiError struct {
+	errs []error
+}
+
+// Добавление ошибки; nil игнорируется
+func (m *MultiError) Add(err error) {
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+}
+
+// Возвращает nil, если ошибок не было, иначе саму MultiError
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.errs), strings.Join(messages, "; "))
+}
+
+// errors.Is и errors.As проверяют каждую вложенную ошибку
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
+
+// Цепочка запасных вариантов: вызывает функции по порядку и возвращает первый
+// успешный результат. Если все упали, возвращает MultiError со всеми ошибками.
+func FirstSuccess[T any](fns ...func() (T, error)) (T, error) {
+	var zero T
+	if len(fns) == 0 {
+		return zero, ErrNoFallbacks
+	}
+
+	var errs MultiError
+	for i, fn := range fns {
+		v, err := fn()
+		if err == nil {
+			return v, nil
+		}
+		errs.Add(fmt.Errorf("source %d: %w", i+1, err))
+	}
+	return zero, errs.ErrorOrNil()
+}
+
+type User struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+func validateUser(u User) error {
+	var errs MultiError
+	if u.Name == "" {
+		errs.Add(ErrEmptyName)
+	}
+	if u.Age < 0 || u.Age > 150 {
+		errs.Add(fmt.Errorf("%w: %d", ErrInvalidAge, u.Age))
+	}
+	if !strings.Contains(u.Email, "@") {
+		errs.Add(ErrInvalidMail)
+	}
+	return errs.ErrorOrNil()
+}
+
+func main() {
+	err := validateUser(User{Name: "", Age: 200, Email: "bob.example.com"})
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// Поиск конкретной ошибки среди накопленных
+	if errors.Is(err, ErrInvalidAge) {
+		fmt.Println("Age must be fixed")
+	}
+
+	var multi *MultiError
+	if errors.As(err, &multi) {
+		fmt.Println("Number of errors:", len(multi.Unwrap()))
+	}
+
+	if err := validateUser(User{Name: "Alice", Age: 30, Email: "alice@example.com"}); err == nil {
+		fmt.Println("User is valid.")
+	}
+
+	// Запасные источники данных: кэш, реплика, основная база
+	errCacheMiss := errors.New("cache miss")
+	fromCache := func() (string, error) { return "", errCacheMiss }
+	fromReplica := func() (string, error) { return "", errors.New("replica is down") }
+	fromPrimary := func() (string, error) { return "alice", nil }
+
+	name, err := FirstSuccess(fromCache, fromReplica, fromPrimary)
+	fmt.Println("First success:", name, err)
+
+	_, err = FirstSuccess(fromCache, fromReplica)
+	fmt.Println("All failed:", err)
+	fmt.Println("Cache miss among them:", errors.Is(err, errCacheMiss))
+
+	_, err = FirstSuccess[string]()
+	fmt.Println("No fallbacks:", err)
+}
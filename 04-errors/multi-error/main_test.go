@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	var errs MultiError
+	errs.Add(ErrEmptyName)
+	errs.Add(nil) // nil игнорируется
+	errs.Add(ErrInvalidMail)
+
+	err := errs.ErrorOrNil()
+	if err == nil {
+		t.Fatal("ErrorOrNil returned nil with two errors added")
+	}
+	if !errors.Is(err, ErrEmptyName) || !errors.Is(err, ErrInvalidMail) {
+		t.Errorf("errors.Is does not find the added sentinels in %v", err)
+	}
+	if errors.Is(err, ErrInvalidAge) {
+		t.Errorf("errors.Is finds %v which was never added", ErrInvalidAge)
+	}
+	if len(errs.Unwrap()) != 2 {
+		t.Errorf("Unwrap returned %d errors, expected 2", len(errs.Unwrap()))
+	}
+	expected := "2 errors occurred: name is empty; email is invalid"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q, expected %q", err.Error(), expected)
+	}
+}
+
+func TestMultiErrorEmpty(t *testing.T) {
+	var errs MultiError
+	errs.Add(nil)
+	if err := errs.ErrorOrNil(); err != nil {
+		t.Errorf("ErrorOrNil() = %v, expected nil", err)
+	}
+
+	var nilMulti *MultiError
+	if err := nilMulti.ErrorOrNil(); err != nil {
+		t.Errorf("nil MultiError ErrorOrNil() = %v, expected nil", err)
+	}
+}
+
+func TestValidateUser(t *testing.T) {
+	if err := validateUser(User{Name: "Alice", Age: 30, Email: "alice@example.com"}); err != nil {
+		t.Errorf("valid user: unexpected error %v", err)
+	}
+
+	err := validateUser(User{Age: 200, Email: "bob.example.com"})
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Unwrap()) != 3 {
+		t.Errorf("invalid user: expected MultiError with 3 errors, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidAge) {
+		t.Errorf("invalid user: errors.Is(%v, ErrInvalidAge) = false", err)
+	}
+}
+
+func TestFirstSuccess(t *testing.T) {
+	var calls []int
+	source := func(id int, value string, err error) func() (string, error) {
+		return func() (string, error) {
+			calls = append(calls, id)
+			return value, err
+		}
+	}
+	errDown := errors.New("down")
+
+	v, err := FirstSuccess(source(1, "", errDown), source(2, "replica", nil), source(3, "primary", nil))
+	if v != "replica" || err != nil {
+		t.Errorf("FirstSuccess() = %q, %v, expected \"replica\", nil", v, err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("called sources %v, expected to stop after the first success", calls)
+	}
+}
+
+func TestFirstSuccessAllFailed(t *testing.T) {
+	errCacheMiss := errors.New("cache miss")
+	errDown := errors.New("replica is down")
+
+	v, err := FirstSuccess(
+		func() (int, error) { return 1, errCacheMiss },
+		func() (int, error) { return 2, errDown },
+	)
+	if v != 0 {
+		t.Errorf("FirstSuccess() value = %d, expected zero value on failure", v)
+	}
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Unwrap()) != 2 {
+		t.Fatalf("FirstSuccess() error = %v, expected MultiError with 2 errors", err)
+	}
+	if !errors.Is(err, errCacheMiss) || !errors.Is(err, errDown) {
+		t.Errorf("errors.Is does not find source errors in %v", err)
+	}
+	expected := "2 errors occurred: source 1: cache miss; source 2: replica is down"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q, expected %q", err.Error(), expected)
+	}
+}
+
+func TestFirstSuccessEmpty(t *testing.T) {
+	if _, err := FirstSuccess[string](); !errors.Is(err, ErrNoFallbacks) {
+		t.Errorf("FirstSuccess() = %v, expected %v", err, ErrNoFallbacks)
+	}
+}
@@ -0,0 +1,39 @@
+// Пример Must-функции: паника вместо возврата ошибки, как в regexp.MustCompile.
+// Подходит для инициализации пакета и тестов, где ошибка означает баг в программе.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Возвращает v, если err == nil, иначе паникует с err
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+// Значение, которое обязано разобраться при старте программы
+var defaultPort = Must(strconv.Atoi("8080"))
+
+func main() {
+	fmt.Println("Default port:", defaultPort)
+	fmt.Println("Result:", Must(divide(10, 2)))
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Recovered from panic:", r)
+		}
+	}()
+	fmt.Println("Result:", Must(divide(10, 0)))
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMust(t *testing.T) {
+	if got := Must(divide(10, 2)); got != 5 {
+		t.Errorf("Must(divide(10, 2)) = %d, expected 5", got)
+	}
+	if got := Must("value", nil); got != "value" {
+		t.Errorf("Must(\"value\", nil) = %q, expected \"value\"", got)
+	}
+}
+
+func TestMustPanics(t *testing.T) {
+	errFailed := errors.New("failed")
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, errFailed) {
+			t.Errorf("Must panicked with %v, expected %v", r, errFailed)
+		}
+	}()
+	Must(0, errFailed)
+	t.Error("Must with an error did not panic")
+}
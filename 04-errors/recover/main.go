@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"runtime/debug"
+)
 
 func safeDivide(a, b int) (result int, err error) {
 	defer func() {
@@ -15,6 +18,24 @@ func safeDivide(a, b int) (result int, err error) {
 	return a / b, nil
 }
 
+// Восстановление после паники с сохранением стека вызовов для диагностики.
+// Если паники не было, оба результата равны nil.
+func RecoverWithStack(fn func()) (recovered any, stack []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			recovered = r
+			stack = debug.Stack()
+		}
+	}()
+	fn()
+	return nil, nil
+}
+
+func parseConfig() {
+	var settings map[string]int
+	settings["timeout"] = 30 // Паника: запись в nil map
+}
+
 func main() {
 	result, err := safeDivide(10, 0)
 	if err != nil {
@@ -22,4 +43,10 @@ func main() {
 	} else {
 		fmt.Println("Result:", result)
 	}
+
+	// Стек показывает, в какой функции произошла паника
+	if r, stack := RecoverWithStack(parseConfig); r != nil {
+		fmt.Println("Recovered:", r)
+		fmt.Printf("Stack:\n%s", stack)
+	}
 }
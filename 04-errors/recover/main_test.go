@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecoverWithStack(t *testing.T) {
+	recovered, stack := RecoverWithStack(parseConfig)
+	if recovered == nil {
+		t.Fatal("RecoverWithStack did not recover the panic")
+	}
+	if !strings.Contains(string(stack), "parseConfig") {
+		t.Errorf("stack does not mention the panicking function:\n%s", stack)
+	}
+}
+
+func TestRecoverWithStackNoPanic(t *testing.T) {
+	called := false
+	recovered, stack := RecoverWithStack(func() { called = true })
+	if !called {
+		t.Error("fn was not called")
+	}
+	if recovered != nil || stack != nil {
+		t.Errorf("RecoverWithStack = %v, %q, expected nil, nil", recovered, stack)
+	}
+}
+
+func TestSafeDivide(t *testing.T) {
+	if result, err := safeDivide(10, 2); err != nil || result != 5 {
+		t.Errorf("safeDivide(10, 2) = %d, %v, expected 5, nil", result, err)
+	}
+	if _, err := safeDivide(10, 0); err == nil {
+		t.Error("safeDivide(10, 0) returned no error")
+	}
+}
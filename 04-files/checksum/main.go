@@ -0,0 +1,62 @@
+//Контрольная сумма файла на основе FNV-1a
+//Файл читается блоками через буферизированный reader, поэтому размер файла не ограничен памятью.
+//Позволяет дешево определить, изменилось ли содержимое файла.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+)
+
+// Контрольная сумма данных из r (64-битный FNV-1a).
+// Для пустого входа возвращается начальное значение FNV-1a: 0xcbf29ce484222325.
+func ChecksumReader(r io.Reader) (uint64, error) {
+	h := fnv.New64a()
+	if _, err := io.Copy(h, bufio.NewReader(r)); err != nil {
+		return 0, err
+	}
+	return h.Sum64(), nil
+}
+
+// Контрольная сумма содержимого файла
+func ChecksumFile(path string) (uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	return ChecksumReader(file)
+}
+
+func main() {
+	file, err := os.CreateTemp("", "checksum-*.txt")
+	if err != nil {
+		fmt.Println("Error creating file:", err)
+		return
+	}
+	defer os.Remove(file.Name())
+	file.Close()
+
+	// Пустой файл
+	sum, err := ChecksumFile(file.Name())
+	fmt.Printf("Empty file: %#x, error: %v\n", sum, err)
+
+	// Содержимое изменилось — контрольная сумма тоже
+	for _, content := range []string{"Hello, Go!", "Hello, Go!", "Hello, Go?"} {
+		if err := os.WriteFile(file.Name(), []byte(content), 0644); err != nil {
+			fmt.Println("Error writing file:", err)
+			return
+		}
+		sum, err := ChecksumFile(file.Name())
+		if err != nil {
+			fmt.Println("Error reading file:", err)
+			return
+		}
+		fmt.Printf("%q: %#x\n", content, sum)
+	}
+}
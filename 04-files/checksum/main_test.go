@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChecksumReader(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected uint64
+	}{
+		{"", 0xcbf29ce484222325},
+		{"a", 0xaf63dc4c8601ec8c},
+		{"foobar", 0x85944171f73967e8},
+	}
+
+	for _, test := range tests {
+		got, err := ChecksumReader(strings.NewReader(test.input))
+		if err != nil || got != test.expected {
+			t.Errorf("ChecksumReader(%q) = %#x, %v, expected %#x, nil", test.input, got, err, test.expected)
+		}
+	}
+}
+
+func TestChecksumFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.txt")
+	write := func(content string) uint64 {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		sum, err := ChecksumFile(path)
+		if err != nil {
+			t.Fatalf("ChecksumFile() error = %v", err)
+		}
+		return sum
+	}
+
+	first := write("Hello, Go!")
+	if second := write("Hello, Go!"); second != first {
+		t.Errorf("checksum of the same content changed: %#x != %#x", second, first)
+	}
+	if changed := write("Hello, Go?"); changed == first {
+		t.Errorf("checksum did not change for different content: %#x", changed)
+	}
+	if empty := write(""); empty != 0xcbf29ce484222325 {
+		t.Errorf("checksum of empty file = %#x, expected 0xcbf29ce484222325", empty)
+	}
+}
+
+func TestChecksumFileMissing(t *testing.T) {
+	if _, err := ChecksumFile(filepath.Join(t.TempDir(), "missing.txt")); !os.IsNotExist(err) {
+		t.Errorf("ChecksumFile(missing) error = %v, expected not-exist error", err)
+	}
+}
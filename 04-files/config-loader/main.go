@@ -0,0 +1,156 @@
+// Загрузка конфигурации: значения из JSON-файла, поверх них — переменные окружения
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+type Config struct {
+	Host    string        `json:"host" env:"APP_HOST"`
+	Port    int           `json:"port" env:"APP_PORT"`
+	Debug   bool          `json:"debug" env:"APP_DEBUG"`
+	Timeout time.Duration `json:"timeout" env:"APP_TIMEOUT"` // В JSON — наносекунды, в env — "5s"
+	DB      struct {
+		DSN      string `json:"dsn" env:"APP_DB_DSN"`
+		MaxConns int    `json:"max_conns" env:"APP_DB_MAX_CONNS"`
+	} `json:"db"`
+}
+
+// Читает JSON из path в T, затем переопределяет поля с тегом env значениями
+// соответствующих переменных окружения (вложенные структуры обходятся рекурсивно).
+// Если файла нет, конфигурация собирается только из окружения.
+// Значение env, которое не разбирается в тип поля, — ошибка.
+func LoadConfig[T any](path string) (T, error) {
+	var cfg T
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return cfg, err
+	default:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+
+	v := reflect.ValueOf(&cfg).Elem()
+	if v.Kind() != reflect.Struct {
+		return cfg, fmt.Errorf("config must be a struct, got %s", v.Kind())
+	}
+	if err := applyEnv(v); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func applyEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if value.Kind() == reflect.Struct {
+			if err := applyEnv(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setFromString(value, raw); err != nil {
+			return fmt.Errorf("env %s for field %s: %w", name, field.Name, err)
+		}
+	}
+	return nil
+}
+
+func setFromString(value reflect.Value, raw string) error {
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+	return nil
+}
+
+func main() {
+	dir, err := os.MkdirTemp("", "config")
+	if err != nil {
+		fmt.Println("Error creating temp dir:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	content := `{"host": "localhost", "port": 8080, "db": {"dsn": "postgres://localhost/app", "max_conns": 10}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		fmt.Println("Error writing config:", err)
+		return
+	}
+
+	// Файл задает основу, окружение переопределяет отдельные поля
+	os.Setenv("APP_PORT", "9090")
+	os.Setenv("APP_DB_MAX_CONNS", "50")
+	os.Setenv("APP_TIMEOUT", "5s")
+	cfg, err := LoadConfig[Config](path)
+	fmt.Printf("Config: %+v Error: %v\n", cfg, err)
+
+	// Файла нет — конфигурация только из окружения
+	cfg, err = LoadConfig[Config](filepath.Join(dir, "missing.json"))
+	fmt.Printf("Env-only config: %+v Error: %v\n", cfg, err)
+
+	// Значение не разбирается в тип поля
+	os.Setenv("APP_DEBUG", "maybe")
+	_, err = LoadConfig[Config](path)
+	fmt.Println("Error:", err)
+}
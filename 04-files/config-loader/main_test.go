@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigEnvOverride(t *testing.T) {
+	path := writeConfig(t, `{"host": "localhost", "port": 8080, "db": {"dsn": "postgres://localhost/app", "max_conns": 10}}`)
+	t.Setenv("APP_PORT", "9090")
+	t.Setenv("APP_DB_MAX_CONNS", "50")
+	t.Setenv("APP_TIMEOUT", "5s")
+
+	cfg, err := LoadConfig[Config](path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Host != "localhost" || cfg.DB.DSN != "postgres://localhost/app" {
+		t.Errorf("file values lost: %+v", cfg)
+	}
+	if cfg.Port != 9090 || cfg.DB.MaxConns != 50 || cfg.Timeout != 5*time.Second {
+		t.Errorf("env overrides not applied: %+v", cfg)
+	}
+}
+
+func TestLoadConfigEnvOnly(t *testing.T) {
+	t.Setenv("APP_HOST", "example.com")
+	t.Setenv("APP_DEBUG", "true")
+
+	cfg, err := LoadConfig[Config](filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadConfig without file returned error: %v", err)
+	}
+	if cfg.Host != "example.com" || !cfg.Debug || cfg.Port != 0 {
+		t.Errorf("LoadConfig() = %+v, expected host and debug from env only", cfg)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	valid := `{"host": "localhost"}`
+	tests := []struct {
+		name     string
+		content  string
+		env      map[string]string
+		contains string
+	}{
+		{"invalid bool", valid, map[string]string{"APP_DEBUG": "maybe"}, "APP_DEBUG"},
+		{"invalid int", valid, map[string]string{"APP_PORT": "http"}, "APP_PORT"},
+		{"int overflow", valid, map[string]string{"APP_PORT": "99999999999999999999"}, "APP_PORT"},
+		{"invalid duration", valid, map[string]string{"APP_TIMEOUT": "5"}, "APP_TIMEOUT"},
+		{"nested field", valid, map[string]string{"APP_DB_MAX_CONNS": "many"}, "MaxConns"},
+		{"broken json", `{"host": `, nil, "parse"},
+		{"type mismatch in json", `{"port": "8080"}`, nil, "parse"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			_, err := LoadConfig[Config](writeConfig(t, test.content))
+			if err == nil {
+				t.Fatal("LoadConfig returned nil error")
+			}
+			if !strings.Contains(err.Error(), test.contains) {
+				t.Errorf("error %q does not mention %q", err, test.contains)
+			}
+		})
+	}
+}
+
+func TestLoadConfigNotStruct(t *testing.T) {
+	if _, err := LoadConfig[map[string]string](filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadConfig into a map returned nil error")
+	}
+}
+
+func TestLoadConfigUnsupportedField(t *testing.T) {
+	type withSlice struct {
+		Tags []string `env:"APP_TAGS"`
+	}
+	t.Setenv("APP_TAGS", "a,b")
+	if _, err := LoadConfig[withSlice](filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadConfig with unsupported field type returned nil error")
+	}
+}
@@ -0,0 +1,78 @@
+//Копирование данных с подсчетом байт
+//Полезно для отображения прогресса при чтении больших файлов.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// Обертка над io.Reader, которая считает прочитанные байты
+type CountingReader struct {
+	r     io.Reader
+	count atomic.Int64
+}
+
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+// Текущее количество прочитанных байт (можно вызывать из другой горутины)
+func (c *CountingReader) Count() int64 {
+	return c.count.Load()
+}
+
+// Копирование из src в dst. Возвращает количество байт, которые действительно
+// были записаны в dst: при частичной записи учитывается только записанная часть.
+func CopyCounting(dst io.Writer, src io.Reader) (n int64, err error) {
+	buf := make([]byte, 32*1024)
+	for {
+		nr, readErr := src.Read(buf)
+		if nr > 0 {
+			nw, writeErr := dst.Write(buf[:nr])
+			n += int64(nw)
+			if writeErr != nil {
+				return n, writeErr
+			}
+			if nw != nr {
+				return n, io.ErrShortWrite
+			}
+		}
+		if readErr == io.EOF {
+			return n, nil
+		}
+		if readErr != nil {
+			return n, readErr
+		}
+	}
+}
+
+func main() {
+	data := strings.Repeat("x", 100*1024)
+
+	// Копирование с отслеживанием прогресса чтения
+	src := NewCountingReader(strings.NewReader(data))
+	var dst bytes.Buffer
+	n, err := CopyCounting(&dst, src)
+	fmt.Println("Copied:", n, "Read:", src.Count(), "Error:", err)
+
+	// Читатель закрывает канал посередине: счетчик отражает только записанные байты
+	pr, pw := io.Pipe()
+	go func() {
+		io.CopyN(io.Discard, pr, 50*1024)
+		pr.CloseWithError(errors.New("reader gone"))
+	}()
+	n, err = CopyCounting(pw, strings.NewReader(data))
+	fmt.Println("Copied before failure:", n, "Error:", err)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCopyCounting(t *testing.T) {
+	data := strings.Repeat("x", 100*1024)
+
+	src := NewCountingReader(strings.NewReader(data))
+	var dst bytes.Buffer
+	n, err := CopyCounting(&dst, src)
+	if err != nil {
+		t.Fatalf("CopyCounting returned error: %v", err)
+	}
+	if n != int64(len(data)) || src.Count() != int64(len(data)) || dst.String() != data {
+		t.Errorf("CopyCounting copied %d bytes, read %d, expected %d", n, src.Count(), len(data))
+	}
+}
+
+func TestCopyCountingWriterFails(t *testing.T) {
+	data := strings.Repeat("x", 100*1024)
+	tests := []struct {
+		limit int
+	}{
+		{0},
+		{1},
+		{32 * 1024},    // Ошибка ровно на границе буфера
+		{50*1024 + 7},  // Частичная запись посередине буфера
+		{100*1024 - 1}, // Не хватило одного байта
+	}
+
+	for _, test := range tests {
+		n, err := CopyCounting(&failingWriter{limit: test.limit}, strings.NewReader(data))
+		if err == nil {
+			t.Errorf("limit %d: CopyCounting returned no error", test.limit)
+		}
+		if n != int64(test.limit) {
+			t.Errorf("limit %d: CopyCounting = %d bytes, expected %d", test.limit, n, test.limit)
+		}
+	}
+}
+
+// Writer, который молча записывает меньше, чем просили, без ошибки
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	return len(p) / 2, nil
+}
+
+func TestCopyCountingShortWrite(t *testing.T) {
+	n, err := CopyCounting(shortWriter{}, strings.NewReader("abcdef"))
+	if !errors.Is(err, io.ErrShortWrite) || n != 3 {
+		t.Errorf("CopyCounting = %d, %v, expected 3, %v", n, err, io.ErrShortWrite)
+	}
+}
+
+// Writer, который принимает только limit байт, а затем возвращает ошибку
+type failingWriter struct {
+	limit   int
+	written int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.written+len(p) > w.limit {
+		n := w.limit - w.written
+		w.written = w.limit
+		return n, errors.New("disk full")
+	}
+	w.written += len(p)
+	return len(p), nil
+}
@@ -0,0 +1,76 @@
+// Глубокое копирование через encoding/gob:
+// значение кодируется в буфер и сразу декодируется в новую переменную, поэтому вложенные
+// структуры, map и слайсы копируются, а не разделяются с оригиналом.
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+)
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type Person struct {
+	Name      string
+	Addresses []Address
+	Tags      map[string]string
+	notes     string // Неэкспортируемые поля gob не видит: в копии будет нулевое значение
+}
+
+type Event struct {
+	Name    string
+	Payload any // Конкретный тип в интерфейсе должен быть зарегистрирован через gob.Register
+}
+
+type Click struct {
+	X, Y int
+}
+
+// Возвращает независимую глубокую копию v.
+// Неэкспортируемые поля не копируются; типы внутри интерфейсных полей
+// должны быть зарегистрированы через gob.Register, иначе вернется ошибка.
+func DeepClone[T any](v T) (T, error) {
+	var clone T
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&v); err != nil {
+		return clone, fmt.Errorf("deep clone %T: encode: %w", v, err)
+	}
+	if err := gob.NewDecoder(&buf).Decode(&clone); err != nil {
+		return clone, fmt.Errorf("deep clone %T: decode: %w", v, err)
+	}
+	return clone, nil
+}
+
+func main() {
+	original := Person{
+		Name:      "Alice",
+		Addresses: []Address{{City: "Berlin", Street: "Main St"}},
+		Tags:      map[string]string{"role": "admin"},
+		notes:     "private",
+	}
+
+	clone, err := DeepClone(original)
+	if err != nil {
+		fmt.Println("Error cloning:", err)
+		return
+	}
+
+	// Изменения копии не затрагивают оригинал
+	clone.Addresses[0].City = "Paris"
+	clone.Tags["role"] = "guest"
+
+	fmt.Printf("Original: %+v\n", original)
+	fmt.Printf("Clone:    %+v\n", clone)
+
+	// Тип Click не зарегистрирован — gob вернет понятную ошибку
+	_, err = DeepClone(Event{Name: "click", Payload: Click{X: 1, Y: 2}})
+	fmt.Println("Error cloning:", err)
+
+	gob.Register(Click{})
+	event, err := DeepClone(Event{Name: "click", Payload: Click{X: 1, Y: 2}})
+	fmt.Printf("Cloned event: %+v, error: %v\n", event, err)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/gob"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeepClone(t *testing.T) {
+	original := Person{
+		Name:      "Alice",
+		Addresses: []Address{{City: "Berlin", Street: "Main St"}},
+		Tags:      map[string]string{"role": "admin"},
+	}
+
+	clone, err := DeepClone(original)
+	if err != nil {
+		t.Fatalf("DeepClone() error = %v", err)
+	}
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("DeepClone() = %+v, expected %+v", clone, original)
+	}
+
+	clone.Name = "Bob"
+	clone.Addresses[0].City = "Paris"
+	clone.Tags["role"] = "guest"
+	if original.Name != "Alice" || original.Addresses[0].City != "Berlin" || original.Tags["role"] != "admin" {
+		t.Errorf("original changed after modifying clone: %+v", original)
+	}
+}
+
+func TestDeepCloneUnexportedFields(t *testing.T) {
+	clone, err := DeepClone(Person{Name: "Alice", notes: "private"})
+	if err != nil {
+		t.Fatalf("DeepClone() error = %v", err)
+	}
+	if clone.notes != "" {
+		t.Errorf("clone.notes = %q, expected unexported field to be skipped", clone.notes)
+	}
+}
+
+type scroll struct {
+	Delta int
+}
+
+type drag struct {
+	From, To int
+}
+
+func TestDeepCloneInterfaceField(t *testing.T) {
+	_, err := DeepClone(Event{Name: "scroll", Payload: scroll{Delta: 3}})
+	if err == nil {
+		t.Fatal("DeepClone() with unregistered type = nil error, expected an error")
+	}
+	if !strings.Contains(err.Error(), "deep clone") || !strings.Contains(err.Error(), "not registered") {
+		t.Errorf("DeepClone() error = %q, expected it to mention the unregistered type", err)
+	}
+
+	gob.Register(drag{})
+	event, err := DeepClone(Event{Name: "drag", Payload: drag{From: 1, To: 5}})
+	if err != nil {
+		t.Fatalf("DeepClone() with registered type error = %v", err)
+	}
+	if payload, ok := event.Payload.(drag); !ok || payload != (drag{From: 1, To: 5}) {
+		t.Errorf("cloned Payload = %#v, expected drag{From: 1, To: 5}", event.Payload)
+	}
+}
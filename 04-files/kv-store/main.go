@@ -0,0 +1,181 @@
+// Key-value хранилище с сериализацией через encoding/gob и подменяемым бэкендом:
+// Store не знает, куда сохраняются байты, — в файл или в память (удобно для тестов).
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Место хранения сериализованного состояния. Load для пустого хранилища
+// возвращает nil без ошибки.
+type Backend interface {
+	Save(data []byte) error
+	Load() ([]byte, error)
+}
+
+// Бэкенд в файле. Запись идет во временный файл с последующим переименованием,
+// поэтому при сбое посреди записи старые данные не повреждаются.
+type FileBackend struct {
+	Path string
+}
+
+func (b FileBackend) Save(data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(b.Path), filepath.Base(b.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // После успешного Rename файла уже нет, ошибка игнорируется
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), b.Path)
+}
+
+func (b FileBackend) Load() ([]byte, error) {
+	data, err := os.ReadFile(b.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Бэкенд в памяти
+type MemoryBackend struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (b *MemoryBackend) Save(data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append([]byte(nil), data...) // Копия: вызывающий может переиспользовать слайс
+	return nil
+}
+
+func (b *MemoryBackend) Load() ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.data...), nil
+}
+
+// Хранилище строк по ключу. Каждое изменение сразу сохраняется в бэкенд.
+type Store struct {
+	mu      sync.RWMutex
+	backend Backend
+	data    map[string]string
+}
+
+// Открывает хранилище и загружает из бэкенда сохраненное ранее состояние
+func NewStore(backend Backend) (*Store, error) {
+	s := &Store{backend: backend, data: make(map[string]string)}
+
+	raw, err := backend.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load store: %w", err)
+	}
+	if len(raw) > 0 {
+		if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&s.data); err != nil {
+			return nil, fmt.Errorf("decode store: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *Store) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.data[key]
+	return v, ok
+}
+
+func (s *Store) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, existed := s.data[key]
+	s.data[key] = value
+	if err := s.persist(); err != nil {
+		// Откатываем изменение, чтобы память не расходилась с бэкендом
+		if existed {
+			s.data[key] = old
+		} else {
+			delete(s.data, key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, existed := s.data[key]
+	if !existed {
+		return nil
+	}
+	delete(s.data, key)
+	if err := s.persist(); err != nil {
+		s.data[key] = old
+		return err
+	}
+	return nil
+}
+
+func (s *Store) persist() error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.data); err != nil {
+		return fmt.Errorf("encode store: %w", err)
+	}
+	if err := s.backend.Save(buf.Bytes()); err != nil {
+		return fmt.Errorf("save store: %w", err)
+	}
+	return nil
+}
+
+func main() {
+	dir, err := os.MkdirTemp("", "kv-store")
+	if err != nil {
+		fmt.Println("Error creating temp dir:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	// Store работает одинаково с любым бэкендом; в тестах используется MemoryBackend
+	backends := map[string]Backend{
+		"file":   FileBackend{Path: filepath.Join(dir, "store.gob")},
+		"memory": &MemoryBackend{},
+	}
+	for _, name := range []string{"file", "memory"} {
+		store, err := NewStore(backends[name])
+		if err != nil {
+			fmt.Println(name, "backend error:", err)
+			continue
+		}
+		store.Set("user:1", "alice")
+		store.Set("user:2", "bob")
+		store.Delete("user:2")
+
+		// Новое хранилище поверх того же бэкенда видит сохраненные данные
+		reopened, err := NewStore(backends[name])
+		if err != nil {
+			fmt.Println(name, "backend error:", err)
+			continue
+		}
+		alice, _ := reopened.Get("user:1")
+		_, hasBob := reopened.Get("user:2")
+		fmt.Printf("%s backend after reopen: user:1 = %q, user:2 present = %v\n", name, alice, hasBob)
+	}
+}
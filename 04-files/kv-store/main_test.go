@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Один и тот же набор проверок для каждого бэкенда
+func TestStore(t *testing.T) {
+	backends := []struct {
+		name       string
+		newBackend func(t *testing.T) Backend
+	}{
+		{"file", func(t *testing.T) Backend {
+			return FileBackend{Path: filepath.Join(t.TempDir(), "store.gob")}
+		}},
+		{"memory", func(t *testing.T) Backend {
+			return &MemoryBackend{}
+		}},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name+"/empty", func(t *testing.T) {
+			store, err := NewStore(backend.newBackend(t))
+			if err != nil {
+				t.Fatalf("NewStore on empty backend: %v", err)
+			}
+			if v, ok := store.Get("missing"); ok {
+				t.Errorf("Get(missing) = %q, true, expected not found", v)
+			}
+		})
+
+		t.Run(backend.name+"/persist", func(t *testing.T) {
+			b := backend.newBackend(t)
+			store, err := NewStore(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, err := range []error{
+				store.Set("user:1", "alice"),
+				store.Set("user:2", "bob"),
+				store.Set("user:1", "alice2"),
+				store.Delete("user:2"),
+				store.Delete("user:3"), // Удаление отсутствующего ключа — не ошибка
+			} {
+				if err != nil {
+					t.Fatalf("store operation failed: %v", err)
+				}
+			}
+
+			reopened, err := NewStore(b)
+			if err != nil {
+				t.Fatalf("NewStore on saved backend: %v", err)
+			}
+			if v, ok := reopened.Get("user:1"); !ok || v != "alice2" {
+				t.Errorf("Get(user:1) after reopen = %q, %v, expected \"alice2\", true", v, ok)
+			}
+			if _, ok := reopened.Get("user:2"); ok {
+				t.Error("deleted key user:2 is still present after reopen")
+			}
+		})
+	}
+}
+
+// Бэкенд, который отказывает при сохранении
+type failingBackend struct {
+	MemoryBackend
+	err error
+}
+
+func (b *failingBackend) Save(data []byte) error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.MemoryBackend.Save(data)
+}
+
+func TestStoreRollbackOnSaveError(t *testing.T) {
+	backend := &failingBackend{}
+	store, err := NewStore(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Set("kept", "v1")
+
+	backend.err = errors.New("disk full")
+	if err := store.Set("kept", "v2"); !errors.Is(err, backend.err) {
+		t.Errorf("Set with failing backend = %v, expected %v", err, backend.err)
+	}
+	if err := store.Set("new", "v"); !errors.Is(err, backend.err) {
+		t.Errorf("Set with failing backend = %v, expected %v", err, backend.err)
+	}
+	if err := store.Delete("kept"); !errors.Is(err, backend.err) {
+		t.Errorf("Delete with failing backend = %v, expected %v", err, backend.err)
+	}
+
+	// Память не расходится с бэкендом
+	if v, ok := store.Get("kept"); !ok || v != "v1" {
+		t.Errorf("Get(kept) = %q, %v, expected \"v1\", true", v, ok)
+	}
+	if _, ok := store.Get("new"); ok {
+		t.Error("key from failed Set is present")
+	}
+}
+
+func TestNewStoreCorruptData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.gob")
+	if err := os.WriteFile(path, []byte("not gob"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStore(FileBackend{Path: path}); err == nil {
+		t.Error("NewStore on corrupt file returned nil error")
+	}
+}
+
+func TestFileBackendLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	backend := FileBackend{Path: filepath.Join(dir, "store.gob")}
+	for i := 0; i < 3; i++ {
+		if err := backend.Save([]byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory contains %d entries after Save, expected only the store file", len(entries))
+	}
+}
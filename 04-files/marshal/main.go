@@ -1,9 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
+	"unicode"
 )
 
 type Person struct {
@@ -12,6 +15,152 @@ type Person struct {
 	Address string `json:"address,omitempty"` // `omitempty` означает, что поле не будет записано в JSON, если оно пустое
 }
 
+// Стиль именования ключей в итоговом JSON
+type KeyStyle int
+
+const (
+	KeyStyleAsIs  KeyStyle = iota // ключи остаются такими, как задано в тегах
+	KeyStyleSnake                 // snake_case
+	KeyStyleCamel                 // camelCase
+)
+
+// Преобразование структуры в JSON с переименованием ключей.
+// Структура сначала маршалится в обычный map, затем ключи переписываются рекурсивно,
+// поэтому вложенные объекты и объекты внутри массивов тоже преобразуются.
+// Числа разбираются как json.Number, чтобы большие int64 не теряли точность.
+// Если два ключа одного объекта после преобразования совпали, возвращается ошибка.
+func MarshalWithKeyStyle(v any, style KeyStyle) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if style == KeyStyleAsIs {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	transformed, err := transformKeys(generic, style)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(transformed)
+}
+
+func transformKeys(value any, style KeyStyle) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		originals := make(map[string]string, len(v)) // Новый ключ -> исходный, для сообщения о коллизии
+		for key, item := range v {
+			converted := convertKey(key, style)
+			if other, ok := originals[converted]; ok {
+				return nil, fmt.Errorf("keys %q and %q both convert to %q", other, key, converted)
+			}
+			originals[converted] = key
+
+			transformed, err := transformKeys(item, style)
+			if err != nil {
+				return nil, err
+			}
+			result[converted] = transformed
+		}
+		return result, nil
+	case []any:
+		for i, item := range v {
+			transformed, err := transformKeys(item, style)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = transformed
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func convertKey(key string, style KeyStyle) string {
+	switch style {
+	case KeyStyleSnake:
+		return toSnakeCase(key)
+	case KeyStyleCamel:
+		return toCamelCase(key)
+	default:
+		return key
+	}
+}
+
+// Разбивает ключ на слова в нижнем регистре по "_" и по смене регистра.
+// Аббревиатура считается одним словом: "HTTPServer" -> ["http", "server"],
+// "userID" -> ["user", "id"].
+func splitWords(s string) []string {
+	var (
+		words   []string
+		current []rune
+	)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if r == '_' {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Новое слово: после строчной буквы или цифры, либо последняя заглавная
+			// аббревиатуры перед строчной ("HTTPServer": граница перед "S")
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+	flush()
+	return words
+}
+
+// "HomeAddress" и "homeAddress" -> "home_address", "HTTPServer" -> "http_server"
+func toSnakeCase(s string) string {
+	return strings.Join(splitWords(s), "_")
+}
+
+// "home_address" и "HomeAddress" -> "homeAddress", "HTTPServer" -> "httpServer"
+func toCamelCase(s string) string {
+	var b strings.Builder
+	for i, word := range splitWords(s) {
+		runes := []rune(word)
+		if i > 0 {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// Структуры с вложенными объектами для демонстрации переименования ключей
+type Contact struct {
+	PhoneNumber string `json:"phone_number"`
+	HomeAddress string `json:"homeAddress"`
+}
+
+type Customer struct {
+	FullName string    `json:"full_name"`
+	Contact  Contact   `json:"contact_info"`
+	Previous []Contact `json:"previousContacts"`
+}
+
 func main() {
 	person := Person{
 		Name:    "Alice",
@@ -26,4 +175,19 @@ func main() {
 	}
 
 	fmt.Println("Marshalled JSON:", string(jsonData)) // Выводим JSON как строку
+
+	// Одна структура, разные соглашения об именовании ключей
+	customer := Customer{
+		FullName: "Bob Smith",
+		Contact:  Contact{PhoneNumber: "+1-555-0100", HomeAddress: "42 Elm St"},
+		Previous: []Contact{{PhoneNumber: "+1-555-0199", HomeAddress: "7 Oak Ave"}},
+	}
+
+	for _, style := range []KeyStyle{KeyStyleAsIs, KeyStyleSnake, KeyStyleCamel} {
+		styled, err := MarshalWithKeyStyle(customer, style)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("Styled JSON:", string(styled))
+	}
 }
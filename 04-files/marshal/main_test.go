@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalWithKeyStyle(t *testing.T) {
+	customer := Customer{
+		FullName: "Bob Smith",
+		Contact:  Contact{PhoneNumber: "+1-555-0100", HomeAddress: "42 Elm St"},
+		Previous: []Contact{{PhoneNumber: "+1-555-0199", HomeAddress: "7 Oak Ave"}},
+	}
+
+	tests := []struct {
+		name     string
+		style    KeyStyle
+		expected map[string]any
+	}{
+		{"snake", KeyStyleSnake, map[string]any{
+			"full_name":    "Bob Smith",
+			"contact_info": map[string]any{"phone_number": "+1-555-0100", "home_address": "42 Elm St"},
+			"previous_contacts": []any{
+				map[string]any{"phone_number": "+1-555-0199", "home_address": "7 Oak Ave"},
+			},
+		}},
+		{"camel", KeyStyleCamel, map[string]any{
+			"fullName":    "Bob Smith",
+			"contactInfo": map[string]any{"phoneNumber": "+1-555-0100", "homeAddress": "42 Elm St"},
+			"previousContacts": []any{
+				map[string]any{"phoneNumber": "+1-555-0199", "homeAddress": "7 Oak Ave"},
+			},
+		}},
+		{"as is", KeyStyleAsIs, map[string]any{
+			"full_name":    "Bob Smith",
+			"contact_info": map[string]any{"phone_number": "+1-555-0100", "homeAddress": "42 Elm St"},
+			"previousContacts": []any{
+				map[string]any{"phone_number": "+1-555-0199", "homeAddress": "7 Oak Ave"},
+			},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := MarshalWithKeyStyle(customer, test.style)
+			if err != nil {
+				t.Fatalf("MarshalWithKeyStyle returned error: %v", err)
+			}
+			var result map[string]any
+			if err := json.Unmarshal(data, &result); err != nil {
+				t.Fatalf("result is not valid JSON: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("MarshalWithKeyStyle = %s, expected %v", data, test.expected)
+			}
+		})
+	}
+}
+
+func TestConvertKey(t *testing.T) {
+	tests := []struct {
+		key          string
+		snake, camel string
+	}{
+		{"HomeAddress", "home_address", "homeAddress"},
+		{"homeAddress", "home_address", "homeAddress"},
+		{"home_address", "home_address", "homeAddress"},
+		{"name", "name", "name"},
+		{"HTTPServer", "http_server", "httpServer"},
+		{"userID", "user_id", "userId"},
+		{"ID", "id", "id"},
+		{"parseURLString", "parse_url_string", "parseUrlString"},
+		{"address2Line", "address2_line", "address2Line"},
+		{"", "", ""},
+	}
+
+	for _, test := range tests {
+		if result := toSnakeCase(test.key); result != test.snake {
+			t.Errorf("toSnakeCase(%q) = %q, expected %q", test.key, result, test.snake)
+		}
+		if result := toCamelCase(test.key); result != test.camel {
+			t.Errorf("toCamelCase(%q) = %q, expected %q", test.key, result, test.camel)
+		}
+	}
+}
+
+func TestMarshalWithKeyStyleLargeNumbers(t *testing.T) {
+	type record struct {
+		UserID  int64   `json:"UserID"`
+		Balance float64 `json:"Balance"`
+	}
+	data, err := MarshalWithKeyStyle(record{UserID: 1<<53 + 1, Balance: 0.1}, KeyStyleSnake)
+	if err != nil {
+		t.Fatalf("MarshalWithKeyStyle returned error: %v", err)
+	}
+	// 2^53 + 1 не представимо в float64 и исказилось бы при разборе в any
+	expected := `{"balance":0.1,"user_id":9007199254740993}`
+	if string(data) != expected {
+		t.Errorf("MarshalWithKeyStyle = %s, expected %s", data, expected)
+	}
+}
+
+func TestMarshalWithKeyStyleCollision(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		style KeyStyle
+	}{
+		{"top level", map[string]int{"userId": 1, "user_id": 2}, KeyStyleSnake},
+		{"nested", map[string]any{"outer": map[string]int{"HomeAddress": 1, "homeAddress": 2}}, KeyStyleCamel},
+		{"inside array", []map[string]int{{"a_b": 1, "aB": 2}}, KeyStyleSnake},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := MarshalWithKeyStyle(test.value, test.style)
+			if err == nil {
+				t.Fatalf("MarshalWithKeyStyle = %s, expected collision error", data)
+			}
+			if !strings.Contains(err.Error(), "both convert to") {
+				t.Errorf("error %q does not describe the collision", err)
+			}
+		})
+	}
+
+	// Без преобразования коллизий нет
+	if _, err := MarshalWithKeyStyle(map[string]int{"userId": 1, "user_id": 2}, KeyStyleAsIs); err != nil {
+		t.Errorf("KeyStyleAsIs returned error: %v", err)
+	}
+}
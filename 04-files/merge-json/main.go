@@ -0,0 +1,85 @@
+//Глубокое слияние двух JSON-объектов
+//Удобно для наложения конфигурации: значения по умолчанию + переопределения из окружения.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+)
+
+// Слияние base и override: при конфликте скаляров побеждает override,
+// вложенные объекты сливаются рекурсивно, массивы заменяются целиком.
+// Если по одному ключу лежат значения разных типов (объект и скаляр), берется override.
+func MergeJSON(base, override []byte) ([]byte, error) {
+	baseMap, err := decodeObject(base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	overrideMap, err := decodeObject(override)
+	if err != nil {
+		return nil, fmt.Errorf("override: %w", err)
+	}
+	if baseMap == nil || overrideMap == nil {
+		return nil, errors.New("both documents must be JSON objects")
+	}
+	return json.Marshal(mergeMaps(baseMap, overrideMap))
+}
+
+// Разбор документа с числами в виде json.Number: при разборе в float64
+// целые больше 2^53 теряют точность. Данные после первого значения считаются ошибкой,
+// как и в json.Unmarshal.
+func decodeObject(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result map[string]any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON document")
+	}
+	return result, nil
+}
+
+func mergeMaps(base, override map[string]any) map[string]any {
+	result := make(map[string]any, len(base))
+	for key, value := range base {
+		result[key] = value
+	}
+	for key, value := range override {
+		baseObj, baseIsObj := result[key].(map[string]any)
+		overrideObj, overrideIsObj := value.(map[string]any)
+		if baseIsObj && overrideIsObj {
+			result[key] = mergeMaps(baseObj, overrideObj)
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+func main() {
+	defaults := []byte(`{
+		"server": {"host": "localhost", "port": 8080, "tls": {"enabled": false}},
+		"log": {"level": "info"},
+		"features": ["a", "b"],
+		"timeout": {"read": 5, "write": 5}
+	}`)
+	env := []byte(`{
+		"server": {"port": 9090, "tls": {"enabled": true}},
+		"features": ["c"],
+		"timeout": 30
+	}`)
+
+	merged, err := MergeJSON(defaults, env)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Merged JSON:", string(merged))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		base, override string
+		expected       string
+	}{
+		{
+			name:     "nested objects merge",
+			base:     `{"server": {"host": "localhost", "tls": {"enabled": false, "cert": "a.pem"}}}`,
+			override: `{"server": {"tls": {"enabled": true}}}`,
+			expected: `{"server": {"host": "localhost", "tls": {"enabled": true, "cert": "a.pem"}}}`,
+		},
+		{
+			name:     "arrays are replaced",
+			base:     `{"features": ["a", "b"]}`,
+			override: `{"features": ["c"]}`,
+			expected: `{"features": ["c"]}`,
+		},
+		{
+			name:     "scalar overrides object",
+			base:     `{"timeout": {"read": 5}}`,
+			override: `{"timeout": 30}`,
+			expected: `{"timeout": 30}`,
+		},
+		{
+			name:     "object overrides scalar",
+			base:     `{"timeout": 30}`,
+			override: `{"timeout": {"read": 5}}`,
+			expected: `{"timeout": {"read": 5}}`,
+		},
+		{
+			name:     "new keys are added",
+			base:     `{"a": 1}`,
+			override: `{"b": 2}`,
+			expected: `{"a": 1, "b": 2}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := MergeJSON([]byte(test.base), []byte(test.override))
+			if err != nil {
+				t.Fatalf("MergeJSON returned error: %v", err)
+			}
+			var result, expected any
+			if err := json.Unmarshal(merged, &result); err != nil {
+				t.Fatalf("result is not valid JSON: %v", err)
+			}
+			if err := json.Unmarshal([]byte(test.expected), &expected); err != nil {
+				t.Fatalf("bad expected JSON: %v", err)
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("MergeJSON = %s, expected %s", merged, test.expected)
+			}
+		})
+	}
+}
+
+func TestMergeJSONRejectsNonObjects(t *testing.T) {
+	tests := []struct {
+		base, override string
+	}{
+		{`[1, 2]`, `{"a": 1}`},
+		{`{"a": 1}`, `null`},
+		{`{"a": 1`, `{}`},
+		{`{"a": 1} {"b": 2}`, `{}`},
+	}
+
+	for _, test := range tests {
+		if _, err := MergeJSON([]byte(test.base), []byte(test.override)); err == nil {
+			t.Errorf("MergeJSON(%s, %s) returned no error", test.base, test.override)
+		}
+	}
+}
+
+func TestMergeJSONLargeIntegers(t *testing.T) {
+	// 2^53 + 1 и MaxInt64 не представимы в float64 точно
+	base := []byte(`{"id": 9007199254740993, "limits": {"max": 9223372036854775807}}`)
+	override := []byte(`{"limits": {"min": -9007199254740993}}`)
+
+	merged, err := MergeJSON(base, override)
+	if err != nil {
+		t.Fatalf("MergeJSON returned error: %v", err)
+	}
+	expected := `{"id":9007199254740993,"limits":{"max":9223372036854775807,"min":-9007199254740993}}`
+	if string(merged) != expected {
+		t.Errorf("MergeJSON = %s, expected %s", merged, expected)
+	}
+}
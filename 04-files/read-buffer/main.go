@@ -5,24 +5,191 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
+// Размер буфера для чтения строк: длинные строки не упираются в лимит bufio.Scanner (64 КБ)
+const lineBufferSize = 1024 * 1024
+
+// Потоковый фильтр строк (аналог grep): пишет в w только строки, для которых keep вернул true.
+// Возвращает количество записанных строк. Если последняя строка входа не заканчивалась
+// переводом строки, он не добавляется и на выходе.
+func FilterLines(r io.Reader, w io.Writer, keep func(line string) bool) (written int, err error) {
+	reader := bufio.NewReaderSize(r, lineBufferSize)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if len(line) > 0 && keep(strings.TrimSuffix(line, "\n")) {
+			if _, err := io.WriteString(w, line); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, readErr
+		}
+	}
+}
+
+// Построчное чтение с номерами строк (начиная с 1). Останавливается на первой
+// ошибке из fn и возвращает ее. Последняя строка обрабатывается одинаково
+// независимо от того, заканчивается ли вход переводом строки.
+func ScanNumbered(r io.Reader, fn func(lineNo int, line string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), lineBufferSize)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if err := fn(lineNo, scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Параллельная обработка строк файла пулом из workers горутин.
+// Порядок обработки не гарантируется, но каждая строка обрабатывается ровно один раз.
+// Первая ошибка fn останавливает чтение файла и возвращается с номером строки;
+// строки, уже отданные воркерам, при этом еще дорабатываются.
+func ProcessLines(path string, workers int, fn func(line string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type numberedLine struct {
+		no   int
+		text string
+	}
+	var (
+		lines    = make(chan numberedLine)
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for line := range lines {
+				if err := fn(line.text); err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("line %d: %w", line.no, err)
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), lineBufferSize)
+	lineNo := 0
+read:
+	for scanner.Scan() {
+		lineNo++
+		select {
+		case lines <- numberedLine{no: lineNo, text: scanner.Text()}:
+		case <-ctx.Done():
+			break read
+		}
+	}
+	close(lines)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return scanner.Err()
+}
+
 func main() {
 	file, err := os.Open("example.txt")
 	if err != nil {
 		fmt.Println("Error opening file:", err)
+	} else {
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			fmt.Println(scanner.Text()) // Чтение строки и вывод
+		}
+
+		if err := scanner.Err(); err != nil {
+			fmt.Println("Error reading file:", err)
+		}
+	}
+
+	// Фильтрация строк по подстроке
+	input := strings.NewReader("INFO start\nERROR disk full\nINFO retry\nERROR timeout")
+	written, err := FilterLines(input, os.Stdout, func(line string) bool {
+		return strings.Contains(line, "ERROR")
+	})
+	fmt.Println()
+	if err != nil {
+		fmt.Println("Error filtering lines:", err)
 		return
 	}
-	defer file.Close()
+	fmt.Println("Lines written:", written)
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		fmt.Println(scanner.Text()) // Чтение строки и вывод
+	// Нумерация строк и остановка на первой ошибке (как в линтере)
+	source := strings.NewReader("x := 1\nTODO: fix\ny := 2\nTODO: remove")
+	err = ScanNumbered(source, func(lineNo int, line string) error {
+		if strings.HasPrefix(line, "TODO") {
+			return fmt.Errorf("line %d: unresolved TODO", lineNo)
+		}
+		fmt.Printf("%d: %s\n", lineNo, line)
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Lint error:", err)
 	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Println("Error reading file:", err)
+	// Параллельная обработка большого лог-файла
+	logFile, err := os.CreateTemp("", "access-*.log")
+	if err != nil {
+		fmt.Println("Error creating temp file:", err)
+		return
+	}
+	defer os.Remove(logFile.Name())
+	for i := 1; i <= 1000; i++ {
+		status := 200
+		if i == 700 {
+			status = 0 // Поврежденная запись
+		}
+		fmt.Fprintf(logFile, "GET /item/%d %d\n", i, status)
 	}
+	logFile.Close()
+
+	var processed atomic.Int32
+	err = ProcessLines(logFile.Name(), 4, func(line string) error {
+		processed.Add(1)
+		return nil
+	})
+	fmt.Println("Processed lines:", processed.Load(), "Error:", err)
+
+	err = ProcessLines(logFile.Name(), 4, func(line string) error {
+		if strings.HasSuffix(line, " 0") {
+			return fmt.Errorf("malformed status in %q", line)
+		}
+		return nil
+	})
+	fmt.Println("Processing error:", err)
 }
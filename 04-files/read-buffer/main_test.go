@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFilterLines(t *testing.T) {
+	containsError := func(line string) bool { return strings.Contains(line, "ERROR") }
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		written  int
+	}{
+		{"trailing newline", "INFO a\nERROR b\nINFO c\nERROR d\n", "ERROR b\nERROR d\n", 2},
+		{"no trailing newline", "INFO a\nERROR b\nERROR d", "ERROR b\nERROR d", 2},
+		{"nothing matches", "INFO a\nINFO b\n", "", 0},
+		{"empty input", "", "", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out strings.Builder
+			written, err := FilterLines(strings.NewReader(test.input), &out, containsError)
+			if err != nil {
+				t.Fatalf("FilterLines returned error: %v", err)
+			}
+			if out.String() != test.expected || written != test.written {
+				t.Errorf("FilterLines = %q (%d lines), expected %q (%d lines)", out.String(), written, test.expected, test.written)
+			}
+		})
+	}
+}
+
+func TestFilterLinesLongLine(t *testing.T) {
+	long := "ERROR " + strings.Repeat("x", 2*lineBufferSize) // Длиннее буфера чтения
+	input := "INFO short\n" + long + "\n"
+
+	var out strings.Builder
+	written, err := FilterLines(strings.NewReader(input), &out, func(line string) bool {
+		return strings.HasPrefix(line, "ERROR")
+	})
+	if err != nil {
+		t.Fatalf("FilterLines returned error: %v", err)
+	}
+	if written != 1 || out.String() != long+"\n" {
+		t.Errorf("FilterLines wrote %d lines of total length %d, expected 1 line of length %d", written, out.Len(), len(long)+1)
+	}
+}
+
+func TestScanNumbered(t *testing.T) {
+	for _, input := range []string{"one\ntwo\nthree\n", "one\ntwo\nthree"} {
+		var got []string
+		err := ScanNumbered(strings.NewReader(input), func(lineNo int, line string) error {
+			got = append(got, fmt.Sprintf("%d:%s", lineNo, line))
+			return nil
+		})
+		expected := []string{"1:one", "2:two", "3:three"}
+		if err != nil || !reflect.DeepEqual(got, expected) {
+			t.Errorf("ScanNumbered(%q) = %v, %v, expected %v, nil", input, got, err, expected)
+		}
+	}
+}
+
+func TestScanNumberedEarlyStop(t *testing.T) {
+	errStop := errors.New("stop")
+	var seen []int
+	err := ScanNumbered(strings.NewReader("one\ntwo\nthree\n"), func(lineNo int, line string) error {
+		seen = append(seen, lineNo)
+		if line == "two" {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("ScanNumbered() = %v, expected %v", err, errStop)
+	}
+	if !reflect.DeepEqual(seen, []int{1, 2}) {
+		t.Errorf("lines seen = %v, expected [1 2]", seen)
+	}
+}
+
+func writeLines(t *testing.T, count int) string {
+	t.Helper()
+	var content strings.Builder
+	for i := 1; i <= count; i++ {
+		fmt.Fprintf(&content, "line %d\n", i)
+	}
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(content.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProcessLines(t *testing.T) {
+	const count = 500
+	path := writeLines(t, count)
+
+	for _, workers := range []int{0, 1, 4, 16} {
+		var mu sync.Mutex
+		seen := make(map[string]int)
+		err := ProcessLines(path, workers, func(line string) error {
+			mu.Lock()
+			seen[line]++
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ProcessLines(workers=%d) returned error: %v", workers, err)
+		}
+		if len(seen) != count {
+			t.Errorf("ProcessLines(workers=%d) handled %d distinct lines, expected %d", workers, len(seen), count)
+		}
+		for line, n := range seen {
+			if n != 1 {
+				t.Errorf("ProcessLines(workers=%d) handled %q %d times, expected once", workers, line, n)
+			}
+		}
+	}
+}
+
+func TestProcessLinesError(t *testing.T) {
+	path := writeLines(t, 1000)
+	errBad := errors.New("bad line")
+
+	var mu sync.Mutex
+	var handled []string
+	err := ProcessLines(path, 4, func(line string) error {
+		if line == "line 300" {
+			return errBad
+		}
+		mu.Lock()
+		handled = append(handled, line)
+		mu.Unlock()
+		return nil
+	})
+	if !errors.Is(err, errBad) {
+		t.Fatalf("ProcessLines() = %v, expected %v", err, errBad)
+	}
+	if !strings.Contains(err.Error(), "line 300") {
+		t.Errorf("error %q does not mention the failing line number", err)
+	}
+
+	// Чтение останавливается после ошибки, поэтому до конца файла дело не доходит
+	if len(handled) >= 999 {
+		t.Errorf("handled %d lines after error, expected reading to stop early", len(handled))
+	}
+}
+
+func TestProcessLinesMissingFile(t *testing.T) {
+	err := ProcessLines(filepath.Join(t.TempDir(), "missing.txt"), 2, func(string) error { return nil })
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ProcessLines(missing) = %v, expected %v", err, os.ErrNotExist)
+	}
+}
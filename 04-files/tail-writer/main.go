@@ -0,0 +1,60 @@
+//Writer, сохраняющий только последние N байт
+//Полезен для диагностики: хвост длинного вывода можно приложить к сообщению об ошибке.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Реализует io.Writer и хранит только последние limit записанных байт
+type TailWriter struct {
+	mu    sync.Mutex
+	limit int
+	buf   []byte
+}
+
+// Отрицательный limit считается нулевым: такой Writer ничего не сохраняет
+func NewTailWriter(limit int) *TailWriter {
+	limit = max(limit, 0)
+	return &TailWriter{limit: limit, buf: make([]byte, 0, limit)}
+}
+
+// Запись всегда успешна; если данных больше limit, старые байты отбрасываются
+func (w *TailWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := len(p)
+	if len(p) >= w.limit {
+		w.buf = append(w.buf[:0], p[len(p)-w.limit:]...)
+		return n, nil
+	}
+	if overflow := len(w.buf) + len(p) - w.limit; overflow > 0 {
+		w.buf = append(w.buf[:0], w.buf[overflow:]...)
+	}
+	w.buf = append(w.buf, p...)
+	return n, nil
+}
+
+// Копия сохраненного хвоста
+func (w *TailWriter) Bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]byte(nil), w.buf...)
+}
+
+func main() {
+	tail := NewTailWriter(64)
+
+	// Пишем одновременно в консоль и в TailWriter
+	out := io.MultiWriter(os.Stdout, tail)
+	for i := 1; i <= 10; i++ {
+		fmt.Fprintf(out, "step %d: processing batch\n", i)
+	}
+
+	fmt.Printf("\nLast %d bytes for the error report:\n%s", 64, tail.Bytes())
+}
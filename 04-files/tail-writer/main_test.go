@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTailWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		writes   []string
+		expected string
+	}{
+		{"under limit", 10, []string{"abc", "def"}, "abcdef"},
+		{"exactly limit", 6, []string{"abc", "def"}, "abcdef"},
+		{"overflow across writes", 5, []string{"abc", "def", "gh"}, "defgh"},
+		{"single write larger than limit", 4, []string{"0123456789"}, "6789"},
+		{"large write after small", 4, []string{"ab", "0123456789"}, "6789"},
+		{"empty write", 4, []string{"abcd", ""}, "abcd"},
+		{"zero limit", 0, []string{"abc"}, ""},
+		{"negative limit", -1, []string{"abc"}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewTailWriter(test.limit)
+			for _, s := range test.writes {
+				n, err := w.Write([]byte(s))
+				if n != len(s) || err != nil {
+					t.Errorf("Write(%q) = %d, %v, expected %d, nil", s, n, err, len(s))
+				}
+			}
+			if got := string(w.Bytes()); got != test.expected {
+				t.Errorf("Bytes() = %q, expected %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestTailWriterBytesIsCopy(t *testing.T) {
+	w := NewTailWriter(8)
+	fmt.Fprint(w, "tail")
+	b := w.Bytes()
+	b[0] = 'X'
+	if got := string(w.Bytes()); got != "tail" {
+		t.Errorf("Bytes() = %q after modifying previous result, expected \"tail\"", got)
+	}
+}
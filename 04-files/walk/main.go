@@ -0,0 +1,91 @@
+// Рекурсивный обход дерева каталогов с фильтрацией файлов
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Обходит все файлы под root (каталоги в match и fn не передаются) и вызывает fn
+// для файлов, прошедших match. Ошибки не прерывают обход: недоступный подкаталог
+// пропускается, ошибка fn для одного файла не мешает обработать остальные.
+// Все ошибки объединяются через errors.Join; nil — если ошибок не было.
+func WalkFiles(root string, match func(path string, info os.FileInfo) bool, fn func(path string) error) error {
+	var errs []error
+	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Для каталога, который не удалось прочитать, WalkDir сам пропускает содержимое
+			errs = append(errs, err)
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil { // Файл мог быть удален во время обхода
+			errs = append(errs, err)
+			return nil
+		}
+		if !match(path, info) {
+			return nil
+		}
+		if err := fn(path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+		return nil
+	})
+	return errors.Join(append(errs, walkErr)...)
+}
+
+func main() {
+	root, err := os.MkdirTemp("", "walk")
+	if err != nil {
+		fmt.Println("Error creating temp dir:", err)
+		return
+	}
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		"data/2024/jan.csv":   "1,2,3",
+		"data/2024/feb.csv":   "",
+		"data/2024/notes.txt": "not a csv",
+		"data/archive.csv":    "4,5",
+		"readme.md":           "# data",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Println("Error creating dir:", err)
+			return
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			fmt.Println("Error writing file:", err)
+			return
+		}
+	}
+
+	// Обрабатываем все CSV; пустой файл считаем ошибкой, но обход продолжается
+	isCSV := func(path string, info os.FileInfo) bool {
+		return strings.HasSuffix(path, ".csv")
+	}
+	err = WalkFiles(root, isCSV, func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return errors.New("empty file")
+		}
+		rel, _ := filepath.Rel(root, path)
+		fmt.Println("Processed:", rel)
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Walk errors:", err)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func createTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// Обходит root и возвращает относительные пути переданных в fn файлов
+func visited(t *testing.T, root string, match func(string, os.FileInfo) bool) ([]string, error) {
+	t.Helper()
+	var paths []string
+	err := WalkFiles(root, match, func(path string) error {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(paths)
+	return paths, err
+}
+
+func TestWalkFiles(t *testing.T) {
+	root := createTree(t, map[string]string{
+		"data/2024/jan.csv":   "1,2,3",
+		"data/2024/notes.txt": "text",
+		"data/archive.csv":    "4,5",
+		"readme.md":           "# data",
+	})
+	if err := os.MkdirAll(filepath.Join(root, "empty/nested"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		match    func(string, os.FileInfo) bool
+		expected []string
+	}{
+		{"all files", func(string, os.FileInfo) bool { return true },
+			[]string{"data/2024/jan.csv", "data/2024/notes.txt", "data/archive.csv", "readme.md"}},
+		{"csv only", func(path string, _ os.FileInfo) bool { return strings.HasSuffix(path, ".csv") },
+			[]string{"data/2024/jan.csv", "data/archive.csv"}},
+		{"by size", func(_ string, info os.FileInfo) bool { return info.Size() > 4 },
+			[]string{"data/2024/jan.csv", "readme.md"}},
+		{"nothing", func(string, os.FileInfo) bool { return false }, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := visited(t, root, test.match)
+			if err != nil {
+				t.Fatalf("WalkFiles returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("visited %q, expected %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestWalkFilesContinuesAfterFnError(t *testing.T) {
+	root := createTree(t, map[string]string{"a.csv": "", "b.csv": "1", "c.csv": ""})
+	errEmpty := errors.New("empty file")
+
+	var processed []string
+	err := WalkFiles(root, func(string, os.FileInfo) bool { return true }, func(path string) error {
+		processed = append(processed, filepath.Base(path))
+		if info, _ := os.Stat(path); info.Size() == 0 {
+			return errEmpty
+		}
+		return nil
+	})
+
+	if len(processed) != 3 {
+		t.Errorf("processed %q, expected all three files", processed)
+	}
+	if !errors.Is(err, errEmpty) {
+		t.Fatalf("WalkFiles() = %v, expected to wrap %v", err, errEmpty)
+	}
+	for _, name := range []string{"a.csv", "c.csv"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not mention %s", err, name)
+		}
+	}
+}
+
+func TestWalkFilesUnreadableDir(t *testing.T) {
+	root := createTree(t, map[string]string{
+		"open/a.txt":   "a",
+		"locked/b.txt": "b",
+		"z.txt":        "z",
+	})
+	locked := filepath.Join(root, "locked")
+	if err := os.Chmod(locked, 0o000); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(locked, 0o755) // Иначе TempDir не сможет удалить каталог
+	if _, err := os.ReadDir(locked); err == nil {
+		t.Skip("permissions are not enforced for this user")
+	}
+
+	got, err := visited(t, root, func(string, os.FileInfo) bool { return true })
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("WalkFiles() = %v, expected permission error", err)
+	}
+	if expected := []string{"open/a.txt", "z.txt"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("visited %q, expected %q", got, expected)
+	}
+}
+
+func TestWalkFilesMissingRoot(t *testing.T) {
+	_, err := visited(t, filepath.Join(t.TempDir(), "missing"), func(string, os.FileInfo) bool { return true })
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WalkFiles(missing) = %v, expected %v", err, fs.ErrNotExist)
+	}
+}
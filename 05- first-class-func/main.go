@@ -48,6 +48,40 @@ func mapSlice(slice []int, operation func(int) int) []int {
 	return result
 }
 
+// Композиция функций: Compose применяет функции справа налево, Pipe — слева направо.
+// Без аргументов обе возвращают тождественную функцию.
+func Compose[T any](fns ...func(T) T) func(T) T {
+	return func(v T) T {
+		for i := len(fns) - 1; i >= 0; i-- {
+			v = fns[i](v)
+		}
+		return v
+	}
+}
+
+func Pipe[T any](fns ...func(T) T) func(T) T {
+	return func(v T) T {
+		for _, fn := range fns {
+			v = fn(v)
+		}
+		return v
+	}
+}
+
+// Частичное применение: фиксирует первый аргумент функции двух аргументов
+func Partial2[A, B, C any](f func(A, B) C, a A) func(B) C {
+	return func(b B) C {
+		return f(a, b)
+	}
+}
+
+// Каррирование: func(A, B) C превращается в цепочку func(A) func(B) C
+func Curry2[A, B, C any](f func(A, B) C) func(A) func(B) C {
+	return func(a A) func(B) C {
+		return Partial2(f, a)
+	}
+}
+
 func main() {
 	// Использование функций как строительных блоков
 	fmt.Println("Sum:", add(3, 5))
@@ -75,4 +109,16 @@ func main() {
 	numbers := []int{1, 2, 3, 4, 5}
 	squaredNumbers := mapSlice(numbers, func(x int) int { return x * x })
 	fmt.Println("Squared Numbers:", squaredNumbers)
+
+	// Композиция функций: порядок применения имеет значение
+	double := func(x int) int { return x * 2 }
+	increment := func(x int) int { return x + 1 }
+	fmt.Println("Compose(double, increment)(5):", Compose(double, increment)(5)) // double(increment(5)) = 12
+	fmt.Println("Pipe(double, increment)(5):", Pipe(double, increment)(5))       // increment(double(5)) = 11
+
+	// Частичное применение и каррирование
+	addTen := Partial2(add, 10)
+	fmt.Println("Partial2(add, 10)(5):", addTen(5))
+	curriedAdd := Curry2(add)
+	fmt.Println("Curry2(add)(3)(4):", curriedAdd(3)(4))
 }
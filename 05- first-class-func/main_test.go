@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestComposeAndPipe(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	increment := func(n int) int { return n + 1 }
+	square := func(n int) int { return n * n }
+
+	tests := []struct {
+		name     string
+		fn       func(int) int
+		expected int
+	}{
+		{"Compose(double, increment)", Compose(double, increment), 8}, // double(increment(3))
+		{"Pipe(double, increment)", Pipe(double, increment), 7},       // increment(double(3))
+		{"Compose(square, double, inc)", Compose(square, double, increment), 64},
+		{"Pipe(square, double, inc)", Pipe(square, double, increment), 19},
+		{"Compose(double)", Compose(double), 6},
+		{"Pipe(double)", Pipe(double), 6},
+		{"Compose()", Compose[int](), 3},
+		{"Pipe()", Pipe[int](), 3},
+	}
+
+	for _, test := range tests {
+		if got := test.fn(3); got != test.expected {
+			t.Errorf("%s(3) = %d, expected %d", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestPartial2(t *testing.T) {
+	addFive := Partial2(add, 5)
+	tests := []struct {
+		arg, expected int
+	}{
+		{0, 5},
+		{3, 8},
+		{-5, 0},
+	}
+
+	for _, test := range tests {
+		if got := addFive(test.arg); got != test.expected {
+			t.Errorf("Partial2(add, 5)(%d) = %d, expected %d", test.arg, got, test.expected)
+		}
+	}
+}
+
+func TestCurry2(t *testing.T) {
+	curriedAdd := Curry2(add)
+	if got := curriedAdd(2)(3); got != add(2, 3) {
+		t.Errorf("Curry2(add)(2)(3) = %d, expected %d", got, add(2, 3))
+	}
+
+	// Типы аргументов и результата могут различаться
+	format := Curry2(func(base int, n int64) string { return strconv.FormatInt(n, base) })
+	toHex := format(16)
+	if got := toHex(255); got != "ff" {
+		t.Errorf("Curry2(format)(16)(255) = %q, expected \"ff\"", got)
+	}
+	if got := format(2)(5); got != "101" {
+		t.Errorf("Curry2(format)(2)(5) = %q, expected \"101\"", got)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -39,6 +42,50 @@ func reduce(slice []int, accumulator func(int, int) int, initial int) int {
 	return result
 }
 
+// Скользящие окна: все подряд идущие подслайсы длины size (шаг 1).
+// Если size <= 0 или больше длины слайса, возвращается nil.
+func Window[T any](slice []T, size int) [][]T {
+	if size <= 0 || size > len(slice) {
+		return nil
+	}
+	result := make([][]T, 0, len(slice)-size+1)
+	for i := 0; i+size <= len(slice); i++ {
+		result = append(result, slice[i:i+size:i+size])
+	}
+	return result
+}
+
+// Скользящее среднее по окнам длины window (шаг 1). Результат короче data на window-1.
+// Сумма каждого окна считается заново за O(window): если обновлять ее при сдвиге,
+// ошибка округления накапливается, и после большого значения (1e17) средние
+// следующих окон из единиц превращаются в 0.
+func MovingAverage(data []float64, window int) ([]float64, error) {
+	if window <= 0 || window > len(data) {
+		return nil, fmt.Errorf("window %d out of range [1, %d]", window, len(data))
+	}
+	result := make([]float64, 0, len(data)-window+1)
+	for i := window; i <= len(data); i++ {
+		result = append(result, compensatedSum(data[i-window:i])/float64(window))
+	}
+	return result, nil
+}
+
+// Сумма с компенсацией ошибки округления (алгоритм Ноймайера):
+// потерянные младшие разряды копятся отдельно и добавляются в конце
+func compensatedSum(values []float64) float64 {
+	sum, compensation := 0.0, 0.0
+	for _, x := range values {
+		t := sum + x
+		if math.Abs(sum) >= math.Abs(x) {
+			compensation += (sum - t) + x
+		} else {
+			compensation += (x - t) + sum
+		}
+		sum = t
+	}
+	return sum + compensation
+}
+
 // Сортировка с кастомным компаратором
 func sortCustom(slice []int, comparator func(int, int) bool) {
 	sort.Slice(slice, func(i, j int) bool {
@@ -46,6 +93,94 @@ func sortCustom(slice []int, comparator func(int, int) bool) {
 	})
 }
 
+// Вставка в отсортированный слайс с сохранением порядка (бинарный поиск позиции).
+// Равные элементы вставляются после уже имеющихся, как при стабильной сортировке.
+func InsertSorted[T any](s []T, v T, less func(a, b T) bool) []T {
+	i := sort.Search(len(s), func(i int) bool { return less(v, s[i]) })
+	s = append(s, v)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// Перемешивание на месте (алгоритм Фишера–Йетса): все перестановки равновероятны.
+// Генератор r передается снаружи, чтобы с фиксированным seed результат был
+// воспроизводимым; nil означает глобальный генератор пакета rand.
+func Shuffle[T any](s []T, r *rand.Rand) {
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	for i := len(s) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Случайный выбор элемента с вероятностью, пропорциональной его весу.
+// Нулевой вес означает, что элемент никогда не выбирается; отрицательные, NaN
+// и бесконечные веса, а также несовпадение длин считаются ошибкой.
+// nil r означает глобальный генератор.
+func WeightedPick[T any](items []T, weights []float64, r *rand.Rand) (T, error) {
+	var zero T
+	if len(items) != len(weights) {
+		return zero, fmt.Errorf("weighted pick: %d items but %d weights", len(items), len(weights))
+	}
+	total := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return zero, fmt.Errorf("weighted pick: invalid weight %v at index %d", w, i)
+		}
+		total += w
+	}
+	if math.IsInf(total, 0) {
+		return zero, errors.New("weighted pick: sum of weights overflows")
+	}
+	if total == 0 {
+		return zero, errors.New("weighted pick: all weights are zero")
+	}
+
+	float64n := rand.Float64
+	if r != nil {
+		float64n = r.Float64
+	}
+	target := float64n() * total
+	for i, w := range weights {
+		if target < w {
+			return items[i], nil
+		}
+		target -= w
+	}
+	// Из-за погрешности округления target мог не попасть ни в один отрезок
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return items[i], nil
+		}
+	}
+	return zero, errors.New("weighted pick: no positive weight")
+}
+
+// Случайная выборка n разных элементов без возвращения: частичный Фишер–Йетс
+// по копии s, исходный слайс не меняется. n == len(s) дает перемешанную копию.
+// nil r означает глобальный генератор.
+func Sample[T any](s []T, n int, r *rand.Rand) ([]T, error) {
+	if n < 0 || n > len(s) {
+		return nil, fmt.Errorf("sample size %d out of range [0, %d]", n, len(s))
+	}
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+
+	pool := append([]T(nil), s...)
+	for i := 0; i < n; i++ {
+		j := i + intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	// Копия, чтобы маленькая выборка не держала в памяти всю копию s
+	return append(make([]T, 0, n), pool[:n]...), nil
+}
+
 // Обработка ошибок: функция-обёртка для обработки ошибок
 func withErrorHandler(fn func() error) {
 	if err := fn(); err != nil {
@@ -64,7 +199,60 @@ func pipeline(slice []int) int {
 func withLogging(fn func()) {
 	start := time.Now()
 	fn()
-	fmt.Println("Execution time:", time.Since(start))
+	fmt.Println("Execution time:", FormatDuration(time.Since(start)))
+}
+
+// Логирование с выборкой: пропускает в log примерно долю rate сообщений
+// (1.0 — все, 0.0 — ни одного), чтобы горячий путь не заспамил логи.
+// random возвращает число из [0, 1), как rand.Float64; nil — глобальный rand.Float64.
+// Генератор rand.Rand не потокобезопасен: при вызовах из разных горутин оставьте nil.
+func SampledLogger(rate float64, log func(msg string), random func() float64) func(msg string) {
+	if random == nil {
+		random = rand.Float64
+	}
+	return func(msg string) {
+		if random() < rate {
+			log(msg)
+		}
+	}
+}
+
+// Компактный вывод длительности: "1h2m3s", "1.5s", "450ms", "12µs", "0s".
+// Части меньше основной единицы отбрасываются, отрицательные значения получают префикс "-".
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		if d == math.MinInt64 { // -MinInt64 == MinInt64, иначе рекурсия не закончится
+			d++
+		}
+		return "-" + FormatDuration(-d)
+	}
+	switch {
+	case d == 0:
+		return "0s"
+	case d < time.Microsecond:
+		return fmt.Sprintf("%dns", d.Nanoseconds())
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return strconv.FormatFloat(d.Truncate(10*time.Millisecond).Seconds(), 'f', -1, 64) + "s"
+	}
+
+	var b strings.Builder
+	hours := d / time.Hour
+	minutes := d % time.Hour / time.Minute
+	seconds := d % time.Minute / time.Second
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dh", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dm", minutes)
+	}
+	if seconds > 0 {
+		fmt.Fprintf(&b, "%ds", seconds)
+	}
+	return b.String()
 }
 
 // Ретри-логика: повторный вызов в случае ошибки
@@ -78,6 +266,200 @@ func retry(fn func() error, retries int) error {
 	return errors.New("operation failed after retries")
 }
 
+// Ретрай для функций, возвращающих значение: результат первой удачной попытки
+// или последняя ошибка после attempts попыток. Попытки идут без пауз;
+// если нужна задержка между ними, см. RetryWithBackoff.
+func RetryValue[T any](attempts int, fn func() (T, error)) (T, error) {
+	var err error
+	for i := 0; i < max(attempts, 1); i++ {
+		var v T
+		if v, err = fn(); err == nil {
+			return v, nil
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("operation failed after %d attempts: %w", max(attempts, 1), err)
+}
+
+// Классификация ошибок: повторять имеет смысл только временные ошибки
+type Retryable interface {
+	Temporary() bool
+}
+
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string   { return e.err.Error() }
+func (e retryableError) Unwrap() error   { return e.err }
+func (e retryableError) Temporary() bool { return true }
+
+// Помечает ошибку как временную, чтобы RetryWithBackoff повторил вызов
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return retryableError{err: err}
+}
+
+func isRetryable(err error) bool {
+	var r Retryable
+	return errors.As(err, &r) && r.Temporary()
+}
+
+// Настройка RetryWithBackoff
+type RetryOption func(*retryOptions)
+
+type retryOptions struct {
+	jitter float64
+	random func() float64
+}
+
+// Каждая задержка случайно отклоняется на ±jitter (например, 0.2 — это ±20%),
+// чтобы клиенты не повторяли запросы одновременно. jitter ограничивается диапазоном
+// [0, 1]: при большем разбросе задержка могла бы стать отрицательной. random возвращает число из [0, 1) —
+// как rand.Float64; его можно подменить генератором с фиксированным seed.
+// nil означает глобальный rand.Float64.
+func WithJitter(jitter float64, random func() float64) RetryOption {
+	return func(o *retryOptions) {
+		o.jitter = jitter
+		o.random = random
+	}
+}
+
+// Ретри с экспоненциальной задержкой: baseDelay, 2*baseDelay, 4*baseDelay...
+// Постоянные ошибки (не реализующие Retryable) возвращаются сразу, без лишних попыток.
+func RetryWithBackoff(fn func() error, retries int, baseDelay time.Duration, opts ...RetryOption) error {
+	var options retryOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.random == nil {
+		options.random = rand.Float64
+	}
+
+	var err error
+	for i := 0; i < retries; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if !isRetryable(err) {
+			return err
+		}
+		if i < retries-1 {
+			time.Sleep(jitteredDelay(backoffDelay(baseDelay, i), options.jitter, options.random))
+		}
+	}
+	return fmt.Errorf("operation failed after %d retries: %w", retries, err)
+}
+
+// baseDelay*2^attempt; при переполнении int64 задержка ограничивается максимальной длительностью
+func backoffDelay(baseDelay time.Duration, attempt int) time.Duration {
+	if baseDelay <= 0 {
+		return baseDelay
+	}
+	if attempt >= 63 || baseDelay > math.MaxInt64>>attempt {
+		return math.MaxInt64
+	}
+	return baseDelay << attempt
+}
+
+// Задержка в диапазоне [delay*(1-jitter), delay*(1+jitter)); jitter вне [0, 1] приводится к границе.
+// Результат не превышает максимальную длительность.
+func jitteredDelay(delay time.Duration, jitter float64, random func() float64) time.Duration {
+	if !(jitter > 0) { // Отрицательный jitter и NaN
+		return delay
+	}
+	jitter = min(jitter, 1)
+	jittered := float64(delay) * (1 + jitter*(2*random()-1))
+	if jittered >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(jittered)
+}
+
+// Мемоизация рекурсивных функций: рекуррентное соотношение получает recurse —
+// мемоизированную версию самой себя, поэтому каждый аргумент вычисляется один раз.
+// Безопасно для конкурентного использования; блокировка не удерживается во время
+// вычисления, поэтому при одновременных вызовах одно значение может быть посчитано дважды.
+type Memoizer[K comparable, V any] struct {
+	mu    sync.RWMutex
+	cache map[K]V
+	fn    func(recurse func(K) V, k K) V
+}
+
+func NewMemoizer[K comparable, V any](fn func(recurse func(K) V, k K) V) *Memoizer[K, V] {
+	return &Memoizer[K, V]{cache: make(map[K]V), fn: fn}
+}
+
+func (m *Memoizer[K, V]) Get(k K) V {
+	m.mu.RLock()
+	v, ok := m.cache[k]
+	m.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	v = m.fn(m.Get, k)
+
+	m.mu.Lock()
+	m.cache[k] = v
+	m.mu.Unlock()
+	return v
+}
+
+// Мемоизация функции двух аргументов: ключ кэша — пара (a, b) целиком,
+// поэтому fn(1, 2) и fn(2, 1) кэшируются отдельно. В отличие от Memoizer,
+// fn вызывается ровно один раз на пару и при одновременных вызовах:
+// остальные вызывающие ждут результата первого.
+func Cache2[A, B comparable, R any](fn func(A, B) R) func(A, B) R {
+	type args struct {
+		a A
+		b B
+	}
+	type call struct {
+		done  chan struct{}
+		value R
+		ok    bool // false, если fn запаниковала
+	}
+	var (
+		mu    sync.Mutex
+		calls = make(map[args]*call)
+	)
+
+	return func(a A, b B) R {
+		key := args{a, b}
+		for {
+			mu.Lock()
+			c, found := calls[key]
+			if !found {
+				c = &call{done: make(chan struct{})}
+				calls[key] = c
+				mu.Unlock()
+
+				defer func() {
+					if !c.ok {
+						// Не кэшируем панику: следующий вызов попробует снова
+						mu.Lock()
+						delete(calls, key)
+						mu.Unlock()
+					}
+					close(c.done)
+				}()
+				c.value = fn(a, b)
+				c.ok = true
+				return c.value
+			}
+			mu.Unlock()
+
+			<-c.done
+			if c.ok {
+				return c.value
+			}
+		}
+	}
+}
+
 // Троттлинг: ограничение частоты вызова
 func throttle(fn func(), duration time.Duration) func() {
 	var lastCall time.Time
@@ -89,6 +471,234 @@ func throttle(fn func(), duration time.Duration) func() {
 	}
 }
 
+// Дебаунс с аргументом: fn вызывается через d после последнего вызова,
+// причем с аргументом именно последнего вызова (например, последний поисковый запрос)
+func DebounceArg[T any](fn func(T), d time.Duration) func(T) {
+	var (
+		mu         sync.Mutex
+		timer      *time.Timer
+		latest     T
+		generation int // Защита от срабатывания таймера, который уже был заменен
+	)
+	return func(arg T) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		latest = arg
+		generation++
+		current := generation
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(d, func() {
+			mu.Lock()
+			if current != generation {
+				mu.Unlock()
+				return
+			}
+			value := latest
+			mu.Unlock()
+			fn(value)
+		})
+	}
+}
+
+// Дебаунс по ключам: у каждого ключа (например, id сущности) свой таймер,
+// поэтому частые события одного ключа не откладывают вызовы для других.
+// Выполняется fn из последнего Trigger для ключа.
+type KeyedDebouncer[K comparable] struct {
+	mu      sync.Mutex
+	d       time.Duration
+	pending map[K]*time.Timer
+}
+
+func NewKeyedDebouncer[K comparable](d time.Duration) *KeyedDebouncer[K] {
+	return &KeyedDebouncer[K]{d: d, pending: make(map[K]*time.Timer)}
+}
+
+func (kd *KeyedDebouncer[K]) Trigger(key K, fn func()) {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	if timer, ok := kd.pending[key]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(kd.d, func() {
+		kd.mu.Lock()
+		if kd.pending[key] != timer { // Таймер уже заменен или отменен
+			kd.mu.Unlock()
+			return
+		}
+		delete(kd.pending, key)
+		kd.mu.Unlock()
+		fn()
+	})
+	kd.pending[key] = timer
+}
+
+// Отменяет ожидающий вызов для ключа; остальные ключи не затрагиваются
+func (kd *KeyedDebouncer[K]) Cancel(key K) {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	if timer, ok := kd.pending[key]; ok {
+		timer.Stop()
+		delete(kd.pending, key)
+	}
+}
+
+// Ограничитель частоты со скользящим окном: не более limit событий за любой
+// промежуток длиной window. Хранит время последних событий, устаревшие удаляются,
+// поэтому в памяти никогда не больше limit отметок.
+type SlidingWindowLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	events []time.Time
+}
+
+func NewSlidingWindowLimiter(limit int, window time.Duration) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{limit: limit, window: window}
+}
+
+func (l *SlidingWindowLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-l.window)
+	i := 0
+	for i < len(l.events) && !l.events[i].After(cutoff) {
+		i++
+	}
+	l.events = l.events[i:]
+
+	if len(l.events) >= l.limit {
+		return false
+	}
+	l.events = append(l.events, now)
+	return true
+}
+
+// Бюджет ретраев: повтор разрешен, только пока число ретраев за последние window
+// не превышает ratio от числа успешных запросов (плюс minRetries на холодный старт).
+// При массовом сбое успехов нет, бюджет быстро исчерпывается, и клиенты
+// перестают добивать упавший сервис повторами.
+// События не хранятся по отдельности: окно делится на retryBudgetBuckets интервалов
+// со счетчиками, поэтому память не зависит от нагрузки. Интервал устаревает целиком,
+// так что фактическое окно — от window-window/retryBudgetBuckets до window.
+type RetryBudget struct {
+	mu         sync.Mutex
+	ratio      float64
+	minRetries int
+	bucketSize time.Duration
+	buckets    [retryBudgetBuckets]retryBucket
+}
+
+const retryBudgetBuckets = 10
+
+// Счетчики за один интервал; slot — номер интервала с начала эпохи Unix
+type retryBucket struct {
+	slot      int64
+	successes int
+	retries   int
+}
+
+func NewRetryBudget(ratio float64, minRetries int, window time.Duration) *RetryBudget {
+	return &RetryBudget{
+		ratio:      ratio,
+		minRetries: minRetries,
+		bucketSize: max(window/retryBudgetBuckets, 1),
+	}
+}
+
+// Можно ли сейчас сделать ретрай. Сам ретрай нужно отметить через RecordRetry.
+func (b *RetryBudget) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	successes, retries := b.totals(time.Now())
+	budget := float64(b.minRetries) + b.ratio*float64(successes)
+	return float64(retries) < budget
+}
+
+func (b *RetryBudget) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current(time.Now()).successes++
+}
+
+func (b *RetryBudget) RecordRetry() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current(time.Now()).retries++
+}
+
+// Интервал для момента now; устаревший интервал на его месте в кольце обнуляется
+func (b *RetryBudget) current(now time.Time) *retryBucket {
+	slot := now.UnixNano() / int64(b.bucketSize)
+	bucket := &b.buckets[slot%retryBudgetBuckets]
+	if bucket.slot != slot {
+		*bucket = retryBucket{slot: slot}
+	}
+	return bucket
+}
+
+// Сумма счетчиков по интервалам, попадающим в окно
+func (b *RetryBudget) totals(now time.Time) (successes, retries int) {
+	slot := now.UnixNano() / int64(b.bucketSize)
+	for _, bucket := range b.buckets {
+		if age := slot - bucket.slot; age >= 0 && age < retryBudgetBuckets {
+			successes += bucket.successes
+			retries += bucket.retries
+		}
+	}
+	return successes, retries
+}
+
+// Троттлинг с сохранением последнего вызова (как throttle в lodash):
+// leading — вызвать fn сразу в начале окна, trailing — по окончании окна вызвать fn
+// с аргументом последнего подавленного вызова. Если оба флага false, fn не вызывается.
+func Throttle[T any](fn func(T), window time.Duration, leading, trailing bool) func(T) {
+	var (
+		mu         sync.Mutex
+		active     bool // Идет ли сейчас окно троттлинга
+		pending    bool // Был ли подавленный вызов в текущем окне
+		pendingArg T
+	)
+
+	var endWindow func()
+	endWindow = func() {
+		mu.Lock()
+		if trailing && pending {
+			arg := pendingArg
+			pending = false
+			time.AfterFunc(window, endWindow) // Отложенный вызов открывает новое окно
+			mu.Unlock()
+			fn(arg)
+			return
+		}
+		active, pending = false, false
+		mu.Unlock()
+	}
+
+	return func(arg T) {
+		mu.Lock()
+		if !active {
+			active = true
+			time.AfterFunc(window, endWindow)
+			if leading {
+				mu.Unlock()
+				fn(arg)
+				return
+			}
+		}
+		pending, pendingArg = true, arg
+		mu.Unlock()
+	}
+}
+
 // Middleware в веб-приложениях (имитация)
 func middleware(fn func()) func() {
 	return func() {
@@ -125,6 +735,39 @@ func parallelProcessing(slice []int, worker func(int) int) []int {
 	return result
 }
 
+// Параллельный map-reduce: mapper выполняется на workers горутинах,
+// а свертка идет последовательно в порядке входных элементов, поэтому результат детерминирован.
+// Для пустого входа возвращается init.
+func MapReduce[T, M, R any](items []T, workers int, mapper func(T) M, reducer func(R, M) R, init R) R {
+	if workers < 1 {
+		workers = 1
+	}
+	mapped := make([]M, len(items))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				mapped[i] = mapper(items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	result := init
+	for _, m := range mapped {
+		result = reducer(result, m)
+	}
+	return result
+}
+
 func main() {
 	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
@@ -140,10 +783,55 @@ func main() {
 	sum := reduce(numbers, func(a, b int) int { return a + b }, 0)
 	fmt.Println("Sum of numbers:", sum)
 
+	// Скользящее среднее по окнам из трёх элементов
+	for _, w := range Window(numbers, 3) {
+		avg := float64(reduce(w, func(a, b int) int { return a + b }, 0)) / float64(len(w))
+		fmt.Println("Window:", w, "Average:", avg)
+	}
+
+	// То же для временного ряда, без промежуточных слайсов
+	temperatures := []float64{20, 22, 21, 25, 24, 26}
+	smoothed, err := MovingAverage(temperatures, 3)
+	fmt.Println("Moving average:", smoothed, err)
+	if _, err := MovingAverage(temperatures, 10); err != nil {
+		fmt.Println("Moving average error:", err)
+	}
+
 	// Сортировка с кастомным компаратором (по убыванию)
 	sortCustom(numbers, func(a, b int) bool { return a > b })
 	fmt.Println("Sorted Numbers:", numbers)
 
+	// Поддержание упорядоченного слайса при добавлении элементов
+	var ordered []int
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		ordered = InsertSorted(ordered, v, func(a, b int) bool { return a < b })
+	}
+	fmt.Println("Insert Sorted:", ordered)
+
+	// Перемешивание с фиксированным seed повторяется от запуска к запуску
+	deck := []string{"A", "K", "Q", "J", "10"}
+	Shuffle(deck, rand.New(rand.NewSource(7)))
+	fmt.Println("Shuffled:", deck)
+
+	// Взвешенный выбор: частоты примерно соответствуют весам 10% / 30% / 60%
+	servers := []string{"small", "medium", "large"}
+	picker := rand.New(rand.NewSource(1))
+	picks := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		server, _ := WeightedPick(servers, []float64{1, 3, 6}, picker)
+		picks[server]++
+	}
+	fmt.Println("Weighted picks:", picks)
+	_, err = WeightedPick(servers, []float64{0, 0, 0}, nil)
+	fmt.Println("All-zero weights:", err)
+
+	// Случайная выборка без повторов
+	testUsers := []string{"alice", "bob", "carol", "dave", "erin", "frank"}
+	sample, err := Sample(testUsers, 3, rand.New(rand.NewSource(5)))
+	fmt.Println("Sample of 3:", sample, err)
+	_, err = Sample(testUsers, 10, nil)
+	fmt.Println("Sample of 10:", err)
+
 	// Обработка ошибок через обёртку
 	withErrorHandler(func() error {
 		return errors.New("this is a test error")
@@ -158,6 +846,19 @@ func main() {
 		fmt.Println("Function executed")
 	})
 
+	// Логируем примерно 1% событий горячего пути
+	logged := 0
+	logSample := SampledLogger(0.01, func(msg string) { logged++ }, rand.New(rand.NewSource(3)).Float64)
+	for i := 0; i < 10000; i++ {
+		logSample(fmt.Sprintf("cache hit %d", i))
+	}
+	fmt.Println("Sampled log lines:", logged, "of", 10000)
+
+	// Человекочитаемые длительности
+	for _, d := range []time.Duration{0, 12 * time.Microsecond, 450 * time.Millisecond, 1500 * time.Millisecond, time.Hour + 2*time.Minute + 3*time.Second, -90 * time.Second} {
+		fmt.Println("Duration:", FormatDuration(d))
+	}
+
 	// Ретри-логика
 	retryErr := retry(func() error {
 		if rand.Float32() < 0.7 {
@@ -167,6 +868,77 @@ func main() {
 	}, 5)
 	fmt.Println("Retry result:", retryErr)
 
+	// Ретрай с возвратом значения: вторая попытка успешна
+	tries := 0
+	profile, err := RetryValue(3, func() (string, error) {
+		tries++
+		if tries < 2 {
+			return "", errors.New("connection reset")
+		}
+		return "alice", nil
+	})
+	fmt.Println("Retry value:", profile, err, "Attempts:", tries)
+	_, err = RetryValue(2, func() (int, error) { return 0, errors.New("service unavailable") })
+	fmt.Println("Retry value exhausted:", err)
+
+	// Ретри с backoff: временная ошибка повторяется, постоянная — нет
+	attempts := 0
+	backoffErr := RetryWithBackoff(func() error {
+		attempts++
+		if attempts < 3 {
+			return MarkRetryable(errors.New("connection reset"))
+		}
+		return nil
+	}, 5, 100*time.Millisecond)
+	fmt.Println("Backoff result:", backoffErr, "Attempts:", attempts)
+
+	attempts = 0
+	backoffErr = RetryWithBackoff(func() error {
+		attempts++
+		return errors.New("invalid request")
+	}, 5, 100*time.Millisecond)
+	fmt.Println("Backoff result:", backoffErr, "Attempts:", attempts)
+
+	// Задержки с jitter ±20%: генератор с фиксированным seed дает воспроизводимый результат
+	seeded := rand.New(rand.NewSource(42))
+	for i := 0; i < 3; i++ {
+		base := (100 * time.Millisecond) << i
+		fmt.Println("Jittered delay:", jitteredDelay(base, 0.2, seeded.Float64),
+			"Bounds:", time.Duration(float64(base)*0.8), "-", time.Duration(float64(base)*1.2))
+	}
+	attempts = 0
+	backoffErr = RetryWithBackoff(func() error {
+		attempts++
+		if attempts < 3 {
+			return MarkRetryable(errors.New("rate limited"))
+		}
+		return nil
+	}, 5, 100*time.Millisecond, WithJitter(0.2, seeded.Float64))
+	fmt.Println("Jitter result:", backoffErr, "Attempts:", attempts)
+
+	// Мемоизация рекурсии: Фибоначчи(50) без экспоненциального числа вызовов
+	calls := 0
+	fib := NewMemoizer(func(recurse func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return recurse(n-1) + recurse(n-2)
+	})
+	fmt.Println("Fibonacci(50):", fib.Get(50), "Computed values:", calls)
+
+	// Мемоизация функции двух аргументов
+	powCalls := 0
+	pow := Cache2(func(base, exp int) int {
+		powCalls++
+		result := 1
+		for i := 0; i < exp; i++ {
+			result *= base
+		}
+		return result
+	})
+	fmt.Println("Pow:", pow(2, 10), pow(10, 2), pow(2, 10), pow(10, 2), "Computed:", powCalls)
+
 	// Троттлинг вызовов
 	throttledFunc := throttle(func() { fmt.Println("Throttled function executed") }, time.Second)
 	for i := 0; i < 5; i++ {
@@ -174,6 +946,57 @@ func main() {
 		time.Sleep(300 * time.Millisecond)
 	}
 
+	// Троттлинг с первым и последним вызовом окна
+	savePosition := Throttle(func(pos int) { fmt.Println("Saving scroll position:", pos) }, 200*time.Millisecond, true, true)
+	for pos := 1; pos <= 5; pos++ {
+		savePosition(pos * 100)
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	// Дебаунс поисковых запросов: выполнится только последний
+	search := DebounceArg(func(query string) { fmt.Println("Searching for:", query) }, 200*time.Millisecond)
+	for _, query := range []string{"g", "go", "gol", "golang"} {
+		search(query)
+		time.Sleep(50 * time.Millisecond)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	// Дебаунс по ключам: сохранение каждого документа откладывается независимо
+	saver := NewKeyedDebouncer[string](100 * time.Millisecond)
+	for i := 1; i <= 3; i++ {
+		for _, doc := range []string{"doc-1", "doc-2", "doc-3"} {
+			version := i
+			saver.Trigger(doc, func() { fmt.Println("Saving", doc, "version", version) })
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	saver.Cancel("doc-3") // Документ закрыли без сохранения
+	time.Sleep(200 * time.Millisecond)
+
+	// Скользящее окно: не более 3 запросов за 500 мс
+	limiter := NewSlidingWindowLimiter(3, 500*time.Millisecond)
+	for i := 1; i <= 4; i++ {
+		fmt.Println("Request", i, "allowed:", limiter.Allow())
+	}
+	time.Sleep(500 * time.Millisecond)
+	fmt.Println("Request after window slides allowed:", limiter.Allow())
+
+	// Бюджет ретраев: не больше 10% от успешных запросов за окно
+	budget := NewRetryBudget(0.1, 0, time.Second)
+	for i := 0; i < 20; i++ {
+		budget.RecordSuccess()
+	}
+	for i := 1; budget.Allow(); i++ {
+		budget.RecordRetry()
+		fmt.Println("Retry", i, "allowed")
+	}
+	fmt.Println("Budget exhausted, retry allowed:", budget.Allow())
+	for i := 0; i < 10; i++ {
+		budget.RecordSuccess()
+	}
+	fmt.Println("After more successes, retry allowed:", budget.Allow())
+
 	// Middleware в веб-приложениях
 	wrappedFunction := middleware(func() { fmt.Println("Handling request") })
 	wrappedFunction()
@@ -190,4 +1013,8 @@ func main() {
 	// Параллельная обработка данных
 	squaredResults := parallelProcessing(numbers, func(x int) int { return x * x })
 	fmt.Println("Parallel squared results:", squaredResults)
+
+	// Параллельный map-reduce: сумма квадратов на 4 воркерах
+	sumOfSquares := MapReduce(numbers, 4, func(x int) int { return x * x }, func(acc, x int) int { return acc + x }, 0)
+	fmt.Println("MapReduce sum of squares:", sumOfSquares, "Sequential:", reduce(squaredResults, func(a, b int) int { return a + b }, 0))
 }
@@ -0,0 +1,1065 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		size     int
+		expected [][]int
+	}{
+		{1, [][]int{{1}, {2}, {3}, {4}, {5}}},
+		{3, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{5, [][]int{{1, 2, 3, 4, 5}}},
+		{6, nil},
+		{0, nil},
+		{-1, nil},
+	}
+
+	for _, test := range tests {
+		result := Window(s, test.size)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Window(%v, %d) = %v, expected %v", s, test.size, result, test.expected)
+		}
+		if test.size > 0 && test.size <= len(s) && len(result) != len(s)-test.size+1 {
+			t.Errorf("Window(%v, %d) returned %d windows, expected %d", s, test.size, len(result), len(s)-test.size+1)
+		}
+	}
+}
+
+func TestWindowDoesNotShareCapacity(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	windows := Window(s, 2)
+	windows[0] = append(windows[0], 100) // Не должно перезаписать s[2]
+	if s[2] != 3 {
+		t.Errorf("append to a window overwrote the source slice: %v", s)
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	errTemporary := MarkRetryable(errors.New("connection reset"))
+	errPermanent := errors.New("invalid request")
+
+	tests := []struct {
+		name             string
+		failures         []error // Ошибки первых попыток, затем успех
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{"success first try", nil, 1, nil},
+		{"retryable then success", []error{errTemporary, errTemporary}, 3, nil},
+		{"non-retryable stops at once", []error{errPermanent, nil}, 1, errPermanent},
+		{"retryable until exhausted", []error{errTemporary, errTemporary, errTemporary, errTemporary}, 3, errTemporary},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			err := RetryWithBackoff(func() error {
+				attempts++
+				if attempts <= len(test.failures) {
+					return test.failures[attempts-1]
+				}
+				return nil
+			}, 3, time.Millisecond)
+
+			if attempts != test.expectedAttempts {
+				t.Errorf("attempts = %d, expected %d", attempts, test.expectedAttempts)
+			}
+			if test.expectedErr == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+				t.Errorf("error = %v, expected it to wrap %v", err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		base     time.Duration
+		attempt  int
+		expected time.Duration
+	}{
+		{100 * time.Millisecond, 0, 100 * time.Millisecond},
+		{100 * time.Millisecond, 3, 800 * time.Millisecond},
+		{1, 62, 1 << 62},
+		{1, 63, math.MaxInt64},
+		{time.Hour, 40, math.MaxInt64},
+		{time.Second, 100, math.MaxInt64},
+		{0, 70, 0},
+	}
+
+	for _, test := range tests {
+		if got := backoffDelay(test.base, test.attempt); got != test.expected {
+			t.Errorf("backoffDelay(%v, %d) = %v, expected %v", test.base, test.attempt, got, test.expected)
+		}
+	}
+}
+
+func TestMarkRetryable(t *testing.T) {
+	base := errors.New("timeout")
+	err := MarkRetryable(base)
+	if !isRetryable(err) || !errors.Is(err, base) {
+		t.Errorf("MarkRetryable(%v) is not retryable or does not wrap the original", base)
+	}
+	if isRetryable(base) {
+		t.Errorf("plain error %v is reported as retryable", base)
+	}
+	if !isRetryable(fmt.Errorf("wrapped: %w", err)) {
+		t.Error("wrapped retryable error is not retryable")
+	}
+	if MarkRetryable(nil) != nil {
+		t.Error("MarkRetryable(nil) != nil")
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	tests := []struct {
+		initial  []int
+		values   []int
+		expected []int
+	}{
+		{nil, []int{3}, []int{3}},
+		{[]int{}, []int{2, 1, 3}, []int{1, 2, 3}},
+		{[]int{1, 5, 9}, []int{0, 10, 6}, []int{0, 1, 5, 6, 9, 10}},
+		{[]int{1, 2, 2, 3}, []int{2, 2}, []int{1, 2, 2, 2, 2, 3}},
+	}
+
+	for _, test := range tests {
+		s := append([]int(nil), test.initial...)
+		for _, v := range test.values {
+			s = InsertSorted(s, v, func(a, b int) bool { return a < b })
+		}
+		if !reflect.DeepEqual(s, test.expected) {
+			t.Errorf("InsertSorted(%v, %v) = %v, expected %v", test.initial, test.values, s, test.expected)
+		}
+	}
+}
+
+func TestInsertSortedStable(t *testing.T) {
+	type item struct {
+		key  int
+		name string
+	}
+	byKey := func(a, b item) bool { return a.key < b.key }
+
+	var s []item
+	for _, v := range []item{{2, "first"}, {1, "a"}, {2, "second"}, {3, "b"}, {2, "third"}} {
+		s = InsertSorted(s, v, byKey)
+	}
+	expected := []item{{1, "a"}, {2, "first"}, {2, "second"}, {2, "third"}, {3, "b"}}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("InsertSorted with duplicates = %v, expected %v", s, expected)
+	}
+}
+
+func TestJitteredDelay(t *testing.T) {
+	const base = 100 * time.Millisecond
+	random := rand.New(rand.NewSource(1)).Float64
+	for i := 0; i < 100; i++ {
+		d := jitteredDelay(base, 0.2, random)
+		if d < 80*time.Millisecond || d >= 120*time.Millisecond {
+			t.Fatalf("jitteredDelay(%v, 0.2) = %v, expected within [80ms, 120ms)", base, d)
+		}
+	}
+
+	tests := []struct {
+		jitter, random float64
+		expected       time.Duration
+	}{
+		{0.2, 0, 80 * time.Millisecond},
+		{0.2, 0.5, base},
+		{0.5, 0.75, 125 * time.Millisecond},
+		{0, 0.99, base},
+		{-1, 0.99, base},
+		{math.NaN(), 0, base},
+		{1, 0, 0},
+		{5, 0, 0}, // Ограничивается до 1, задержка не уходит в минус
+		{5, 0.75, 150 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if got := jitteredDelay(base, test.jitter, func() float64 { return test.random }); got != test.expected {
+			t.Errorf("jitteredDelay(%v, %v) with random %v = %v, expected %v", base, test.jitter, test.random, got, test.expected)
+		}
+	}
+}
+
+func TestJitteredDelayNeverNegative(t *testing.T) {
+	random := rand.New(rand.NewSource(1)).Float64
+	for _, jitter := range []float64{1, 1.5, 10, math.Inf(1)} {
+		for i := 0; i < 100; i++ {
+			if d := jitteredDelay(time.Second, jitter, random); d < 0 || d >= 2*time.Second {
+				t.Fatalf("jitteredDelay(1s, %v) = %v, expected within [0, 2s)", jitter, d)
+			}
+		}
+	}
+	if d := jitteredDelay(math.MaxInt64, 0.5, func() float64 { return 0.99 }); d != math.MaxInt64 {
+		t.Errorf("jitteredDelay(MaxInt64, 0.5) = %v, expected MaxInt64", d)
+	}
+}
+
+func TestRetryWithBackoffJitter(t *testing.T) {
+	calls := 0
+	random := func() float64 { calls++; return 0.5 }
+	attempts := 0
+	err := RetryWithBackoff(func() error {
+		attempts++
+		if attempts < 3 {
+			return MarkRetryable(errors.New("rate limited"))
+		}
+		return nil
+	}, 5, time.Millisecond, WithJitter(0.2, random))
+
+	if err != nil || attempts != 3 {
+		t.Errorf("RetryWithBackoff() = %v after %d attempts, expected nil after 3", err, attempts)
+	}
+	if calls != 2 {
+		t.Errorf("random called %d times, expected once per delay (2)", calls)
+	}
+}
+
+func newFibonacci(calls *atomic.Int32) *Memoizer[int, int] {
+	return NewMemoizer(func(recurse func(int) int, n int) int {
+		calls.Add(1)
+		if n < 2 {
+			return n
+		}
+		return recurse(n-1) + recurse(n-2)
+	})
+}
+
+func TestMemoizer(t *testing.T) {
+	var calls atomic.Int32
+	fib := newFibonacci(&calls)
+
+	if got := fib.Get(50); got != 12586269025 {
+		t.Errorf("fib(50) = %d, expected 12586269025", got)
+	}
+	if got := calls.Load(); got != 51 {
+		t.Errorf("recurrence ran %d times, expected once per n in 0..50 (51)", got)
+	}
+	fib.Get(50)
+	fib.Get(10)
+	if got := calls.Load(); got != 51 {
+		t.Errorf("recurrence ran %d times after cached Gets, expected 51", got)
+	}
+}
+
+func TestMemoizerConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	fib := newFibonacci(&calls)
+
+	expected := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := len(expected) - 1; n >= 0; n-- {
+				if got := fib.Get(n); got != expected[n] {
+					t.Errorf("fib(%d) = %d, expected %d", n, got, expected[n])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMapReduce(t *testing.T) {
+	square := func(x int) int { return x * x }
+	sum := func(acc, x int) int { return acc + x }
+
+	items := make([]int, 1000)
+	sequential := 0
+	for i := range items {
+		items[i] = i
+		sequential += square(i)
+	}
+
+	for _, workers := range []int{-1, 0, 1, 4, 2000} {
+		if got := MapReduce(items, workers, square, sum, 0); got != sequential {
+			t.Errorf("MapReduce(%d workers) = %d, expected %d", workers, got, sequential)
+		}
+	}
+}
+
+func TestMapReduceOrder(t *testing.T) {
+	// Свертка идет в порядке исходного слайса, даже если map выполняется параллельно
+	words := []string{"a", "b", "c", "d", "e", "f"}
+	got := MapReduce(words, 3, func(s string) string { return s + s }, func(acc, s string) string { return acc + s }, ">")
+	if got != ">aabbccddeeff" {
+		t.Errorf("MapReduce() = %q, expected \">aabbccddeeff\"", got)
+	}
+}
+
+func TestMapReduceEmpty(t *testing.T) {
+	got := MapReduce(nil, 4, func(x int) int { return x }, func(acc, x int) int { return acc + x }, 42)
+	if got != 42 {
+		t.Errorf("MapReduce(nil) = %d, expected init 42", got)
+	}
+}
+
+func TestSlidingWindowLimiter(t *testing.T) {
+	const limit, window = 3, 50 * time.Millisecond
+	l := NewSlidingWindowLimiter(limit, window)
+	for i := 0; i < limit; i++ {
+		if !l.Allow() {
+			t.Fatalf("Allow() #%d = false, expected true", i+1)
+		}
+	}
+	if l.Allow() {
+		t.Error("Allow() over the limit = true, expected false")
+	}
+
+	time.Sleep(window + 10*time.Millisecond)
+	if !l.Allow() {
+		t.Error("Allow() after the window slid = false, expected true")
+	}
+}
+
+func TestSlidingWindowLimiterPrunes(t *testing.T) {
+	const limit = 2
+	l := NewSlidingWindowLimiter(limit, 5*time.Millisecond)
+	for i := 0; i < 20; i++ {
+		l.Allow()
+		l.Allow()
+		l.Allow()
+		time.Sleep(time.Millisecond)
+	}
+	l.mu.Lock()
+	stored := len(l.events)
+	l.mu.Unlock()
+	if stored > limit {
+		t.Errorf("limiter stores %d timestamps, expected at most %d", stored, limit)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "0s"},
+		{500 * time.Nanosecond, "500ns"},
+		{12 * time.Microsecond, "12µs"},
+		{999 * time.Microsecond, "999µs"},
+		{450 * time.Millisecond, "450ms"},
+		{time.Second, "1s"},
+		{1500 * time.Millisecond, "1.5s"},
+		{1234 * time.Millisecond, "1.23s"},
+		{90 * time.Second, "1m30s"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1h2m3s"},
+		{26 * time.Hour, "26h"},
+		{5*time.Hour + 30*time.Second, "5h30s"},
+		{-450 * time.Millisecond, "-450ms"},
+		{-90 * time.Second, "-1m30s"},
+	}
+
+	for _, test := range tests {
+		if got := FormatDuration(test.d); got != test.expected {
+			t.Errorf("FormatDuration(%v) = %q, expected %q", test.d, got, test.expected)
+		}
+	}
+}
+
+func TestFormatDurationMinInt64(t *testing.T) {
+	if got := FormatDuration(math.MinInt64); !strings.HasPrefix(got, "-2562047h") {
+		t.Errorf("FormatDuration(MinInt64) = %q, expected it to start with \"-2562047h\"", got)
+	}
+}
+
+func TestDebounceArg(t *testing.T) {
+	const d = 200 * time.Millisecond
+	calls := make(chan string, 10)
+	search := DebounceArg(func(q string) { calls <- q }, d)
+
+	// Вызовы подряд без пауз гарантированно укладываются в d даже на медленной машине
+	for _, q := range []string{"g", "go", "gol", "gola", "golang"} {
+		search(q)
+	}
+
+	select {
+	case got := <-calls:
+		if got != "golang" {
+			t.Errorf("debounced call with %q, expected the last argument \"golang\"", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("debounced function was not called")
+	}
+	select {
+	case got := <-calls:
+		t.Errorf("unexpected extra call with %q", got)
+	case <-time.After(2 * d):
+	}
+}
+
+func TestDebounceArgSeparateBursts(t *testing.T) {
+	const d = 20 * time.Millisecond
+	calls := make(chan int, 10)
+	save := DebounceArg(func(v int) { calls <- v }, d)
+
+	receive := func() int {
+		t.Helper()
+		select {
+		case v := <-calls:
+			return v
+		case <-time.After(5 * time.Second):
+			t.Fatal("debounced function was not called")
+			return 0
+		}
+	}
+
+	save(1)
+	save(2)
+	if v := receive(); v != 2 {
+		t.Errorf("first burst called with %d, expected 2", v)
+	}
+	// Следующая серия начинается только после срабатывания предыдущей
+	save(3)
+	if v := receive(); v != 3 {
+		t.Errorf("second burst called with %d, expected 3", v)
+	}
+	select {
+	case v := <-calls:
+		t.Errorf("unexpected extra call with %d", v)
+	case <-time.After(5 * d):
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	tests := []struct {
+		name              string
+		leading, trailing bool
+		expected          []int
+	}{
+		{"leading and trailing", true, true, []int{1, 5}},
+		{"leading only", true, false, []int{1}},
+		{"trailing only", false, true, []int{5}},
+		{"neither", false, false, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var calls []int
+			throttled := Throttle(func(v int) {
+				mu.Lock()
+				calls = append(calls, v)
+				mu.Unlock()
+			}, 50*time.Millisecond, test.leading, test.trailing)
+
+			// Все вызовы укладываются в одно окно
+			for i := 1; i <= 5; i++ {
+				throttled(i)
+			}
+			time.Sleep(150 * time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(calls, test.expected) {
+				t.Errorf("throttled calls = %v, expected %v", calls, test.expected)
+			}
+		})
+	}
+}
+
+func TestThrottleNewWindow(t *testing.T) {
+	var calls atomic.Int32
+	throttled := Throttle(func(int) { calls.Add(1) }, 20*time.Millisecond, true, true)
+
+	throttled(1)
+	time.Sleep(80 * time.Millisecond) // Окно закрылось без подавленных вызовов
+	throttled(2)
+	time.Sleep(80 * time.Millisecond)
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("calls = %d, expected 2 leading calls in separate windows", got)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	budget := NewRetryBudget(0.5, 1, time.Minute)
+
+	// Холодный старт: разрешен только minRetries
+	if !budget.Allow() {
+		t.Fatal("Allow() on fresh budget = false, expected minRetries to be available")
+	}
+	budget.RecordRetry()
+	if budget.Allow() {
+		t.Fatal("Allow() after exhausting minRetries = true, expected false")
+	}
+
+	// Каждый успех добавляет ratio к бюджету: 1 + 0.5 > 1 использованного
+	budget.RecordSuccess()
+	if !budget.Allow() {
+		t.Fatal("Allow() after one success = false, expected true (budget 1.5, used 1)")
+	}
+	budget.RecordRetry()
+	if budget.Allow() {
+		t.Fatal("Allow() after spending replenished retry = true, expected false (budget 1.5, used 2)")
+	}
+	budget.RecordSuccess()
+	budget.RecordSuccess()
+	if !budget.Allow() {
+		t.Error("Allow() after two more successes = false, expected true (budget 2.5, used 2)")
+	}
+}
+
+func TestRetryBudgetWindow(t *testing.T) {
+	window := 30 * time.Millisecond
+	budget := NewRetryBudget(1, 1, window)
+
+	budget.RecordRetry()
+	if budget.Allow() {
+		t.Fatal("Allow() with exhausted budget = true, expected false")
+	}
+
+	// Старые ретраи выпадают из окна, и бюджет восстанавливается
+	time.Sleep(2 * window)
+	if !budget.Allow() {
+		t.Error("Allow() after window passed = false, expected true")
+	}
+
+	// Успехи тоже устаревают: после окна остается только minRetries
+	for i := 0; i < 3; i++ {
+		budget.RecordSuccess()
+	}
+	time.Sleep(2 * window)
+	budget.RecordRetry()
+	if budget.Allow() {
+		t.Error("Allow() with expired successes = true, expected false")
+	}
+}
+
+func TestRetryBudgetHighVolume(t *testing.T) {
+	budget := NewRetryBudget(0.5, 0, time.Hour)
+	for i := 0; i < 100000; i++ {
+		budget.RecordRetry()
+	}
+	for i := 0; i < 200000; i++ {
+		budget.RecordSuccess()
+	}
+	// Бюджет ровно 100000 при 100000 использованных ретраях
+	if budget.Allow() {
+		t.Fatal("Allow() with budget fully used = true, expected false")
+	}
+	budget.RecordSuccess()
+	budget.RecordSuccess()
+	if !budget.Allow() {
+		t.Error("Allow() after two more successes = false, expected true")
+	}
+}
+
+func TestShuffleSeeded(t *testing.T) {
+	deck := []string{"A", "K", "Q", "J", "10"}
+	Shuffle(deck, rand.New(rand.NewSource(7)))
+	// Источник math/rand с фиксированным seed дает одну и ту же последовательность
+	expected := []string{"J", "10", "A", "Q", "K"}
+	if !reflect.DeepEqual(deck, expected) {
+		t.Errorf("Shuffle with seed 7 = %q, expected %q", deck, expected)
+	}
+
+	again := []string{"A", "K", "Q", "J", "10"}
+	Shuffle(again, rand.New(rand.NewSource(7)))
+	if !reflect.DeepEqual(again, deck) {
+		t.Errorf("Shuffle with the same seed = %q, then %q", deck, again)
+	}
+}
+
+func TestShuffleIsPermutation(t *testing.T) {
+	numbers := make([]int, 100)
+	for i := range numbers {
+		numbers[i] = i
+	}
+	Shuffle(numbers, nil) // nil — глобальный генератор
+
+	seen := make([]bool, len(numbers))
+	for _, n := range numbers {
+		if seen[n] {
+			t.Fatalf("Shuffle produced duplicate %d: %v", n, numbers)
+		}
+		seen[n] = true
+	}
+}
+
+func TestShuffleTrivial(t *testing.T) {
+	tests := [][]int{nil, {}, {42}}
+	for _, test := range tests {
+		s := append([]int(nil), test...)
+		Shuffle(s, rand.New(rand.NewSource(1)))
+		if !reflect.DeepEqual(s, append([]int(nil), test...)) {
+			t.Errorf("Shuffle(%v) = %v, expected no-op", test, s)
+		}
+	}
+}
+
+func TestWeightedPickDistribution(t *testing.T) {
+	items := []string{"small", "medium", "large", "never"}
+	weights := []float64{1, 3, 6, 0}
+	r := rand.New(rand.NewSource(1))
+
+	const picks = 100000
+	counts := make(map[string]int)
+	for i := 0; i < picks; i++ {
+		item, err := WeightedPick(items, weights, r)
+		if err != nil {
+			t.Fatalf("WeightedPick returned error: %v", err)
+		}
+		counts[item]++
+	}
+
+	if counts["never"] != 0 {
+		t.Errorf("item with zero weight picked %d times", counts["never"])
+	}
+	for i, item := range items[:3] {
+		got := float64(counts[item]) / picks
+		expected := weights[i] / 10
+		if math.Abs(got-expected) > 0.01 {
+			t.Errorf("share of %q = %.3f, expected %.3f", item, got, expected)
+		}
+	}
+}
+
+func TestWeightedPickErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []int
+		weights []float64
+	}{
+		{"mismatched lengths", []int{1, 2}, []float64{1}},
+		{"empty", nil, nil},
+		{"all zero", []int{1, 2}, []float64{0, 0}},
+		{"negative", []int{1, 2}, []float64{1, -1}},
+		{"NaN", []int{1, 2}, []float64{1, math.NaN()}},
+		{"positive infinity", []int{1, 2}, []float64{1, math.Inf(1)}},
+		{"negative infinity", []int{1, 2}, []float64{1, math.Inf(-1)}},
+		{"overflowing sum", []int{1, 2}, []float64{math.MaxFloat64, math.MaxFloat64}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if v, err := WeightedPick(test.items, test.weights, nil); err == nil {
+				t.Errorf("WeightedPick(%v, %v) = %v, nil, expected error", test.items, test.weights, v)
+			}
+		})
+	}
+}
+
+func TestWeightedPickSingle(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if v, err := WeightedPick([]string{"a", "b", "c"}, []float64{0, 0.5, 0}, nil); err != nil || v != "b" {
+			t.Fatalf("WeightedPick with one positive weight = %q, %v, expected \"b\", nil", v, err)
+		}
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	tests := []struct {
+		data     []float64
+		window   int
+		expected []float64
+	}{
+		{[]float64{20, 22, 21, 25, 24, 26}, 3, []float64{21, 68.0 / 3, 70.0 / 3, 25}},
+		{[]float64{1, 2, 3, 4}, 1, []float64{1, 2, 3, 4}},
+		{[]float64{1, 2, 3, 4}, 4, []float64{2.5}},
+		{[]float64{-1, 1, -1, 1}, 2, []float64{0, 0, 0}},
+	}
+
+	for _, test := range tests {
+		got, err := MovingAverage(test.data, test.window)
+		if err != nil {
+			t.Errorf("MovingAverage(%v, %d) returned error: %v", test.data, test.window, err)
+			continue
+		}
+		if len(got) != len(test.data)-test.window+1 {
+			t.Errorf("MovingAverage(%v, %d) has %d values, expected %d", test.data, test.window, len(got), len(test.data)-test.window+1)
+			continue
+		}
+		for i := range got {
+			if math.Abs(got[i]-test.expected[i]) > 1e-9 {
+				t.Errorf("MovingAverage(%v, %d) = %v, expected %v", test.data, test.window, got, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestMovingAverageLargeMagnitude(t *testing.T) {
+	tests := []struct {
+		data     []float64
+		window   int
+		expected []float64
+	}{
+		// При обновлении суммы на сдвиге здесь получалось [1e17 0 0 0]
+		{[]float64{1e17, 1, 1, 1}, 1, []float64{1e17, 1, 1, 1}},
+		{[]float64{1e17, 1, 1, 1}, 2, []float64{(1e17 + 1) / 2, 1, 1}},
+		{[]float64{1, 1e17, -1e17, 3, 5}, 2, []float64{(1 + 1e17) / 2, 0, -(1e17 - 3) / 2, 4}},
+		{[]float64{1e17, 1, -1e17, 2, 2}, 3, []float64{1.0 / 3, -(1e17 - 3) / 3, -(1e17 - 4) / 3}},
+	}
+
+	for _, test := range tests {
+		got, err := MovingAverage(test.data, test.window)
+		if err != nil {
+			t.Errorf("MovingAverage(%v, %d) returned error: %v", test.data, test.window, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("MovingAverage(%v, %d) = %v, expected %v", test.data, test.window, got, test.expected)
+		}
+	}
+}
+
+func TestMovingAverageInvalidWindow(t *testing.T) {
+	tests := []struct {
+		data   []float64
+		window int
+	}{
+		{[]float64{1, 2, 3}, 0},
+		{[]float64{1, 2, 3}, -1},
+		{[]float64{1, 2, 3}, 4},
+		{nil, 1},
+	}
+
+	for _, test := range tests {
+		if got, err := MovingAverage(test.data, test.window); err == nil {
+			t.Errorf("MovingAverage(%v, %d) = %v, nil, expected error", test.data, test.window, got)
+		}
+	}
+}
+
+func TestSampledLogger(t *testing.T) {
+	const messages = 100000
+	tests := []struct {
+		rate      float64
+		tolerance float64
+	}{
+		{0, 0},
+		{0.01, 0.002},
+		{0.25, 0.01},
+		{0.5, 0.01},
+		{1, 0},
+	}
+
+	for _, test := range tests {
+		logged := 0
+		logger := SampledLogger(test.rate, func(string) { logged++ }, rand.New(rand.NewSource(3)).Float64)
+		for i := 0; i < messages; i++ {
+			logger("message")
+		}
+		if share := float64(logged) / messages; math.Abs(share-test.rate) > test.tolerance {
+			t.Errorf("rate %v: logged %d of %d (%.4f)", test.rate, logged, messages, share)
+		}
+	}
+}
+
+func TestSampledLoggerPassesMessage(t *testing.T) {
+	var got []string
+	logger := SampledLogger(1, func(msg string) { got = append(got, msg) }, nil)
+	logger("first")
+	logger("second")
+	if !reflect.DeepEqual(got, []string{"first", "second"}) {
+		t.Errorf("logged %q, expected [first second]", got)
+	}
+}
+
+func TestCache2(t *testing.T) {
+	calls := make(map[[2]int]int)
+	pow := Cache2(func(base, exp int) int {
+		calls[[2]int{base, exp}]++
+		return int(math.Pow(float64(base), float64(exp)))
+	})
+
+	tests := []struct {
+		base, exp int
+		expected  int
+	}{
+		{2, 10, 1024},
+		{10, 2, 100}, // Те же компоненты в другом порядке — отдельная запись
+		{2, 10, 1024},
+		{10, 2, 100},
+		{3, 3, 27},
+	}
+	for _, test := range tests {
+		if got := pow(test.base, test.exp); got != test.expected {
+			t.Errorf("pow(%d, %d) = %d, expected %d", test.base, test.exp, got, test.expected)
+		}
+	}
+
+	expected := map[[2]int]int{{2, 10}: 1, {10, 2}: 1, {3, 3}: 1}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("fn calls per pair = %v, expected %v", calls, expected)
+	}
+}
+
+func TestCache2Concurrent(t *testing.T) {
+	var calls [4]atomic.Int32
+	slowSum := Cache2(func(a, b int) int {
+		calls[a*2+b].Add(1)
+		time.Sleep(10 * time.Millisecond) // Все горутины успевают прийти за одним ключом
+		return a + b
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a, b := i%2, i/2%2
+			if got := slowSum(a, b); got != a+b {
+				t.Errorf("slowSum(%d, %d) = %d", a, b, got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range calls {
+		if n := calls[i].Load(); n != 1 {
+			t.Errorf("fn(%d, %d) called %d times, expected exactly once", i/2, i%2, n)
+		}
+	}
+}
+
+func TestCache2PanicNotCached(t *testing.T) {
+	attempts := 0
+	flaky := Cache2(func(a, b string) string {
+		attempts++
+		if attempts == 1 {
+			panic("first attempt fails")
+		}
+		return a + b
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic from the first call")
+			}
+		}()
+		flaky("go", "lang")
+	}()
+	if got := flaky("go", "lang"); got != "golang" || attempts != 2 {
+		t.Errorf("flaky after panic = %q with %d attempts, expected \"golang\" with 2", got, attempts)
+	}
+}
+
+// Потокобезопасный журнал вызовов
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) record(s string) func() {
+	return func() {
+		l.mu.Lock()
+		l.calls = append(l.calls, s)
+		l.mu.Unlock()
+	}
+}
+
+func (l *callLog) sorted() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	calls := append([]string(nil), l.calls...)
+	sort.Strings(calls)
+	return calls
+}
+
+func TestKeyedDebouncer(t *testing.T) {
+	var log callLog
+	debouncer := NewKeyedDebouncer[string](30 * time.Millisecond)
+
+	for i := 1; i <= 5; i++ {
+		debouncer.Trigger("doc-1", log.record(fmt.Sprintf("doc-1 v%d", i)))
+		debouncer.Trigger("doc-2", log.record(fmt.Sprintf("doc-2 v%d", i)))
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// Каждый ключ срабатывает один раз с последним fn
+	expected := []string{"doc-1 v5", "doc-2 v5"}
+	if got := log.sorted(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("calls = %q, expected %q", got, expected)
+	}
+}
+
+func TestKeyedDebouncerIndependentKeys(t *testing.T) {
+	var log callLog
+	debouncer := NewKeyedDebouncer[int](30 * time.Millisecond)
+
+	debouncer.Trigger(1, log.record("first"))
+	// Частые события ключа 2 не откладывают ключ 1
+	for i := 0; i < 10; i++ {
+		debouncer.Trigger(2, log.record("second"))
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := log.sorted(); !reflect.DeepEqual(got, []string{"first"}) {
+		t.Errorf("calls while key 2 is busy = %q, expected [first]", got)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if got := log.sorted(); !reflect.DeepEqual(got, []string{"first", "second"}) {
+		t.Errorf("calls = %q, expected [first second]", got)
+	}
+}
+
+func TestKeyedDebouncerCancel(t *testing.T) {
+	var log callLog
+	debouncer := NewKeyedDebouncer[string](20 * time.Millisecond)
+
+	debouncer.Trigger("keep", log.record("keep"))
+	debouncer.Trigger("drop", log.record("drop"))
+	debouncer.Cancel("drop")
+	debouncer.Cancel("missing") // Отмена неизвестного ключа — не ошибка
+	time.Sleep(60 * time.Millisecond)
+
+	if got := log.sorted(); !reflect.DeepEqual(got, []string{"keep"}) {
+		t.Errorf("calls = %q, expected only [keep]", got)
+	}
+
+	// После отмены ключ снова можно запланировать
+	debouncer.Trigger("drop", log.record("drop"))
+	time.Sleep(60 * time.Millisecond)
+	if got := log.sorted(); !reflect.DeepEqual(got, []string{"drop", "keep"}) {
+		t.Errorf("calls = %q, expected [drop keep]", got)
+	}
+}
+
+func TestRetryValue(t *testing.T) {
+	errReset := errors.New("connection reset")
+	tests := []struct {
+		name          string
+		attempts      int
+		failures      int // Сколько первых попыток падает
+		expected      string
+		expectedCalls int
+		wantErr       bool
+	}{
+		{"first attempt", 3, 0, "alice", 1, false},
+		{"second attempt", 3, 1, "alice", 2, false},
+		{"last attempt", 3, 2, "alice", 3, false},
+		{"exhausted", 3, 5, "", 3, true},
+		{"zero attempts means one", 0, 5, "", 1, true},
+		{"negative attempts means one", -2, 0, "alice", 1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			v, err := RetryValue(test.attempts, func() (string, error) {
+				calls++
+				if calls <= test.failures {
+					return "partial", errReset
+				}
+				return "alice", nil
+			})
+
+			if v != test.expected || calls != test.expectedCalls || (err != nil) != test.wantErr {
+				t.Errorf("RetryValue() = %q, %v after %d calls, expected %q (error: %v) after %d calls",
+					v, err, calls, test.expected, test.wantErr, test.expectedCalls)
+			}
+			if test.wantErr && !errors.Is(err, errReset) {
+				t.Errorf("RetryValue() error = %v, expected to wrap %v", err, errReset)
+			}
+		})
+	}
+}
+
+func TestSampleSeeded(t *testing.T) {
+	users := []string{"alice", "bob", "carol", "dave", "erin", "frank"}
+	original := append([]string(nil), users...)
+
+	got, err := Sample(users, 3, rand.New(rand.NewSource(5)))
+	if err != nil {
+		t.Fatalf("Sample returned error: %v", err)
+	}
+	if expected := []string{"alice", "carol", "dave"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Sample with seed 5 = %q, expected %q", got, expected)
+	}
+	if !reflect.DeepEqual(users, original) {
+		t.Errorf("Sample modified its input: %q", users)
+	}
+
+	if again, _ := Sample(users, 3, rand.New(rand.NewSource(5))); !reflect.DeepEqual(again, got) {
+		t.Errorf("Sample with the same seed = %q, then %q", got, again)
+	}
+}
+
+func TestSampleDistinct(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 10, 49, 50} {
+		got, err := Sample(items, n, r)
+		if err != nil || len(got) != n {
+			t.Fatalf("Sample(%d) = %d items, %v, expected %d, nil", n, len(got), err, n)
+		}
+		seen := make(map[int]bool, n)
+		for _, v := range got {
+			if seen[v] {
+				t.Fatalf("Sample(%d) returned duplicate %d", n, v)
+			}
+			seen[v] = true
+		}
+	}
+}
+
+func TestSampleFullIsPermutation(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	got, err := Sample(items, len(items), nil)
+	if err != nil {
+		t.Fatalf("Sample(len) returned error: %v", err)
+	}
+	sorted := append([]int(nil), got...)
+	sort.Ints(sorted)
+	if !reflect.DeepEqual(sorted, items) {
+		t.Errorf("Sample(len) = %v, expected a permutation of %v", got, items)
+	}
+}
+
+func TestSampleDoesNotRetainPool(t *testing.T) {
+	items := make([]int64, 1<<22) // 32 МБ
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	got, err := Sample(items, 1, rand.New(rand.NewSource(1)))
+	if err != nil || len(got) != 1 || cap(got) != 1 {
+		t.Fatalf("Sample(1) = len %d, cap %d, %v, expected len 1, cap 1, nil", len(got), cap(got), err)
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(items)
+	runtime.KeepAlive(got)
+
+	if grown := int64(after.HeapAlloc) - int64(before.HeapAlloc); grown > 8<<20 {
+		t.Errorf("heap grew by %d bytes after Sample(1), expected the copy of the input to be freed", grown)
+	}
+}
+
+func TestSampleOutOfRange(t *testing.T) {
+	for _, n := range []int{-1, 4, 100} {
+		if got, err := Sample([]int{1, 2, 3}, n, nil); err == nil {
+			t.Errorf("Sample(n=%d) = %v, nil, expected error", n, got)
+		}
+	}
+	if got, err := Sample[int](nil, 0, nil); err != nil || len(got) != 0 {
+		t.Errorf("Sample(nil, 0) = %v, %v, expected empty, nil", got, err)
+	}
+}
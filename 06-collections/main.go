@@ -0,0 +1,301 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrKeyNotFound = errors.New("key not found")
+
+// Общий итератор для всех коллекций: Next возвращает следующий элемент
+// и false, когда элементы закончились. Обобщенные алгоритмы ниже работают
+// с любой коллекцией, которая умеет отдавать Iterator.
+type Iterator[T any] interface {
+	Next() (T, bool)
+}
+
+// Итератор по снимку элементов. Изменения коллекции после вызова Iter в нем не видны.
+type sliceIterator[T any] struct {
+	items []T
+	pos   int
+}
+
+func (it *sliceIterator[T]) Next() (T, bool) {
+	if it.pos >= len(it.items) {
+		var zero T
+		return zero, false
+	}
+	v := it.items[it.pos]
+	it.pos++
+	return v, true
+}
+
+// Собирает все оставшиеся элементы итератора в слайс
+func Collect[T any](it Iterator[T]) []T {
+	var result []T
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Ленивый фильтр: элементы проверяются по мере вызова Next
+func Filter[T any](it Iterator[T], predicate func(T) bool) Iterator[T] {
+	return &filterIterator[T]{it: it, predicate: predicate}
+}
+
+type filterIterator[T any] struct {
+	it        Iterator[T]
+	predicate func(T) bool
+}
+
+func (f *filterIterator[T]) Next() (T, bool) {
+	for {
+		v, ok := f.it.Next()
+		if !ok || f.predicate(v) {
+			return v, ok
+		}
+	}
+}
+
+// Пара ключ-значение, которую возвращает итератор OrderedMap
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Map, который помнит порядок добавления ключей.
+// Обновление существующего ключа не меняет его позицию.
+type OrderedMap[K comparable, V any] struct {
+	keys   []K
+	values map[K]V
+}
+
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{values: make(map[K]V)}
+}
+
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func (m *OrderedMap[K, V]) Delete(key K) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Добавляет пары по порядку, как последовательные вызовы Set:
+// повторный ключ обновляет значение, но не меняет позицию
+func (m *OrderedMap[K, V]) SetMany(pairs []Pair[K, V]) {
+	for _, p := range pairs {
+		m.Set(p.Key, p.Value)
+	}
+}
+
+// Перемещает ключ в конец порядка (например, как недавно использованный в LRU)
+func (m *OrderedMap[K, V]) MoveToEnd(key K) error {
+	i, err := m.indexOf(key)
+	if err != nil {
+		return err
+	}
+	copy(m.keys[i:], m.keys[i+1:])
+	m.keys[len(m.keys)-1] = key
+	return nil
+}
+
+// Перемещает ключ в начало порядка
+func (m *OrderedMap[K, V]) MoveToFront(key K) error {
+	i, err := m.indexOf(key)
+	if err != nil {
+		return err
+	}
+	copy(m.keys[1:i+1], m.keys[:i])
+	m.keys[0] = key
+	return nil
+}
+
+func (m *OrderedMap[K, V]) indexOf(key K) (int, error) {
+	if _, ok := m.values[key]; ok {
+		for i, k := range m.keys {
+			if k == key {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+}
+
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Итератор по снимку пар на момент вызова: изменения map во время обхода
+// на него не влияют
+func (m *OrderedMap[K, V]) Iter() Iterator[Pair[K, V]] {
+	pairs := make([]Pair[K, V], len(m.keys))
+	for i, k := range m.keys {
+		pairs[i] = Pair[K, V]{Key: k, Value: m.values[k]}
+	}
+	return &sliceIterator[Pair[K, V]]{items: pairs}
+}
+
+// Множество с порядком обхода, совпадающим с порядком добавления
+type Set[T comparable] struct {
+	items *OrderedMap[T, struct{}]
+}
+
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{items: NewOrderedMap[T, struct{}]()}
+	for _, v := range items {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s *Set[T]) Add(v T)           { s.items.Set(v, struct{}{}) }
+func (s *Set[T]) Remove(v T)        { s.items.Delete(v) }
+func (s *Set[T]) Len() int          { return s.items.Len() }
+func (s *Set[T]) Contains(v T) bool { _, ok := s.items.Get(v); return ok }
+
+// Итератор по снимку элементов на момент вызова, как у OrderedMap
+func (s *Set[T]) Iter() Iterator[T] {
+	return &sliceIterator[T]{items: append([]T(nil), s.items.keys...)}
+}
+
+// Двусвязный список
+type List[T any] struct {
+	head, tail *listNode[T]
+	size       int
+}
+
+type listNode[T any] struct {
+	value      T
+	prev, next *listNode[T]
+}
+
+func (l *List[T]) PushBack(v T) {
+	node := &listNode[T]{value: v, prev: l.tail}
+	if l.tail == nil {
+		l.head = node
+	} else {
+		l.tail.next = node
+	}
+	l.tail = node
+	l.size++
+}
+
+func (l *List[T]) PushFront(v T) {
+	node := &listNode[T]{value: v, next: l.head}
+	if l.head == nil {
+		l.tail = node
+	} else {
+		l.head.prev = node
+	}
+	l.head = node
+	l.size++
+}
+
+func (l *List[T]) Len() int {
+	return l.size
+}
+
+// В отличие от Set и OrderedMap, итератор списка "живой": он идет по ссылкам узлов,
+// поэтому элементы, добавленные в конец во время обхода, тоже будут пройдены.
+// Элементы, добавленные в начало после старта обхода, пропускаются.
+// Итератор, вернувший false, остается исчерпанным.
+func (l *List[T]) Iter() Iterator[T] {
+	return &listIterator[T]{list: l}
+}
+
+type listIterator[T any] struct {
+	list    *List[T]
+	cur     *listNode[T]
+	started bool
+	done    bool
+}
+
+func (it *listIterator[T]) Next() (T, bool) {
+	var zero T
+	if it.done {
+		return zero, false
+	}
+	if !it.started {
+		it.cur, it.started = it.list.head, true
+	} else {
+		it.cur = it.cur.next
+	}
+	if it.cur == nil {
+		it.done = true
+		return zero, false
+	}
+	return it.cur.value, true
+}
+
+func main() {
+	// Одни и те же алгоритмы над разными коллекциями
+	tags := NewSet("go", "rust", "go", "zig")
+	fmt.Println("Set:", Collect(tags.Iter()))
+
+	var queue List[string]
+	queue.PushBack("second")
+	queue.PushBack("third")
+	queue.PushFront("first")
+	fmt.Println("List:", Collect(queue.Iter()))
+
+	ages := NewOrderedMap[string, int]()
+	ages.Set("alice", 30)
+	ages.Set("bob", 17)
+	ages.Set("carol", 25)
+	ages.Set("alice", 31) // Позиция ключа не меняется
+	adults := Filter(ages.Iter(), func(p Pair[string, int]) bool { return p.Value >= 18 })
+	fmt.Println("Adults:", Collect(adults))
+
+	// Пакетная вставка и перестановка ключей
+	steps := NewOrderedMap[string, int]()
+	steps.SetMany([]Pair[string, int]{{"build", 1}, {"test", 2}, {"lint", 3}, {"build", 10}})
+	steps.MoveToEnd("build")
+	steps.MoveToFront("lint")
+	fmt.Println("Steps:", Collect(steps.Iter()))
+	if err := steps.MoveToEnd("deploy"); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	short := Filter(tags.Iter(), func(s string) bool { return len(s) <= 2 })
+	fmt.Println("Short tags:", strings.Join(Collect(short), ", "))
+
+	// Set и OrderedMap обходят снимок: удаление во время обхода не мешает
+	it := tags.Iter()
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		tags.Remove(v)
+	}
+	fmt.Println("Set after removing during iteration:", tags.Len())
+
+	// List обходится "вживую": добавленный в конец элемент тоже будет пройден
+	var tasks List[int]
+	tasks.PushBack(1)
+	tasks.PushBack(2)
+	taskIt := tasks.Iter()
+	for v, ok := taskIt.Next(); ok; v, ok = taskIt.Next() {
+		fmt.Println("Task:", v)
+		if v == 1 {
+			tasks.PushBack(3)
+		}
+	}
+}
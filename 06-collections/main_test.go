@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIteratorOverCollections(t *testing.T) {
+	set := NewSet("go", "rust", "go", "zig")
+
+	var list List[string]
+	list.PushBack("b")
+	list.PushBack("c")
+	list.PushFront("a")
+
+	ordered := NewOrderedMap[string, int]()
+	ordered.Set("x", 1)
+	ordered.Set("y", 2)
+	ordered.Set("x", 3) // Обновление не меняет позицию
+	keys := &mapIterator[string, int]{it: ordered.Iter()}
+
+	tests := []struct {
+		name     string
+		it       Iterator[string]
+		expected []string
+	}{
+		{"set", set.Iter(), []string{"go", "rust", "zig"}},
+		{"list", list.Iter(), []string{"a", "b", "c"}},
+		{"ordered map keys", keys, []string{"x", "y"}},
+		{"empty set", NewSet[string]().Iter(), nil},
+		{"empty list", (&List[string]{}).Iter(), nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Collect(test.it); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Collect() = %q, expected %q", got, test.expected)
+			}
+		})
+	}
+}
+
+// Превращает итератор пар в итератор ключей, чтобы проверять все коллекции одной таблицей
+type mapIterator[K comparable, V any] struct {
+	it Iterator[Pair[K, V]]
+}
+
+func (m *mapIterator[K, V]) Next() (K, bool) {
+	p, ok := m.it.Next()
+	return p.Key, ok
+}
+
+func TestOrderedMapIterValues(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("alice", 30)
+	m.Set("bob", 17)
+	m.Set("alice", 31)
+
+	expected := []Pair[string, int]{{"alice", 31}, {"bob", 17}}
+	if got := Collect(m.Iter()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Collect(Iter()) = %v, expected %v", got, expected)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	var numbers List[int]
+	for i := 1; i <= 6; i++ {
+		numbers.PushBack(i)
+	}
+
+	even := Filter(numbers.Iter(), func(n int) bool { return n%2 == 0 })
+	if got := Collect(even); !reflect.DeepEqual(got, []int{2, 4, 6}) {
+		t.Errorf("Collect(Filter(even)) = %v, expected [2 4 6]", got)
+	}
+	if _, ok := even.Next(); ok {
+		t.Error("exhausted filter iterator returned a value")
+	}
+}
+
+func TestIteratorMutationDuringIteration(t *testing.T) {
+	// Set и OrderedMap обходят снимок на момент вызова Iter
+	set := NewSet(1, 2, 3)
+	setIt := set.Iter()
+	set.Add(4)
+	var seen []int
+	for v, ok := setIt.Next(); ok; v, ok = setIt.Next() {
+		seen = append(seen, v)
+		set.Remove(v)
+	}
+	if !reflect.DeepEqual(seen, []int{1, 2, 3}) || set.Len() != 1 {
+		t.Errorf("set iteration saw %v, len after = %d, expected [1 2 3] and 1", seen, set.Len())
+	}
+
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	mapIt := m.Iter()
+	m.Delete("a")
+	m.Set("b", 2)
+	if got := Collect(mapIt); !reflect.DeepEqual(got, []Pair[string, int]{{"a", 1}}) {
+		t.Errorf("map iteration after mutation = %v, expected snapshot [{a 1}]", got)
+	}
+
+	// List обходится вживую: PushBack виден, PushFront после старта — нет
+	var list List[int]
+	list.PushBack(1)
+	list.PushBack(2)
+	listIt := list.Iter()
+	seen = nil
+	for v, ok := listIt.Next(); ok; v, ok = listIt.Next() {
+		seen = append(seen, v)
+		if v == 1 {
+			list.PushBack(3)
+			list.PushFront(0)
+		}
+	}
+	if !reflect.DeepEqual(seen, []int{1, 2, 3}) {
+		t.Errorf("list iteration saw %v, expected [1 2 3]", seen)
+	}
+
+	// Исчерпанный итератор списка не оживает после добавления
+	list.PushBack(4)
+	if v, ok := listIt.Next(); ok {
+		t.Errorf("exhausted list iterator returned %d after PushBack", v)
+	}
+}
+
+func orderedKeys[K comparable, V any](m *OrderedMap[K, V]) []K {
+	var keys []K
+	for _, p := range Collect(m.Iter()) {
+		keys = append(keys, p.Key)
+	}
+	return keys
+}
+
+func TestOrderedMapSetMany(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("deploy", 0)
+	m.SetMany([]Pair[string, int]{{"build", 1}, {"test", 2}, {"lint", 3}, {"build", 10}, {"deploy", 4}})
+
+	expected := []Pair[string, int]{{"deploy", 4}, {"build", 10}, {"test", 2}, {"lint", 3}}
+	if got := Collect(m.Iter()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("after SetMany = %v, expected %v", got, expected)
+	}
+
+	m.SetMany(nil)
+	if m.Len() != 4 {
+		t.Errorf("Len() after SetMany(nil) = %d, expected 4", m.Len())
+	}
+}
+
+func TestOrderedMapReorder(t *testing.T) {
+	tests := []struct {
+		name     string
+		reorder  func(m *OrderedMap[string, int]) error
+		expected []string
+	}{
+		{"move first to end", func(m *OrderedMap[string, int]) error { return m.MoveToEnd("a") }, []string{"b", "c", "d", "a"}},
+		{"move last to end", func(m *OrderedMap[string, int]) error { return m.MoveToEnd("d") }, []string{"a", "b", "c", "d"}},
+		{"move last to front", func(m *OrderedMap[string, int]) error { return m.MoveToFront("d") }, []string{"d", "a", "b", "c"}},
+		{"move middle to front", func(m *OrderedMap[string, int]) error { return m.MoveToFront("c") }, []string{"c", "a", "b", "d"}},
+		{"move first to front", func(m *OrderedMap[string, int]) error { return m.MoveToFront("a") }, []string{"a", "b", "c", "d"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewOrderedMap[string, int]()
+			m.SetMany([]Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}})
+			if err := test.reorder(m); err != nil {
+				t.Fatalf("reorder returned error: %v", err)
+			}
+			if got := orderedKeys(m); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("keys = %q, expected %q", got, test.expected)
+			}
+			if v, _ := m.Get("c"); v != 3 {
+				t.Errorf("Get(c) after reorder = %d, expected 3", v)
+			}
+		})
+	}
+}
+
+func TestOrderedMapMoveMissingKey(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.SetMany([]Pair[string, int]{{"a", 1}, {"b", 2}})
+
+	if err := m.MoveToEnd("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("MoveToEnd(missing) = %v, expected %v", err, ErrKeyNotFound)
+	}
+	if err := m.MoveToFront("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("MoveToFront(missing) = %v, expected %v", err, ErrKeyNotFound)
+	}
+	if got := orderedKeys(m); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("keys after failed moves = %q, expected [a b]", got)
+	}
+}
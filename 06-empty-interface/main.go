@@ -1,8 +1,16 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // Определение интерфейса
@@ -28,6 +36,181 @@ func checkType(value interface{}) {
 	}
 }
 
+// Ошибка приведения типа: содержит ожидаемый и фактический типы
+type TypeAssertionError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *TypeAssertionError) Error() string {
+	return fmt.Sprintf("type assertion failed: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// Type assertion, возвращающий ошибку вместо флага ok
+func AssertType[T any](v interface{}) (T, error) {
+	result, ok := v.(T)
+	if !ok {
+		return result, &TypeAssertionError{
+			Expected: reflect.TypeOf((*T)(nil)).Elem().String(),
+			Actual:   fmt.Sprintf("%T", v),
+		}
+	}
+	return result, nil
+}
+
+// Ошибка, содержащая список ошибок. Тот же тип, что в 04-errors/multi-error:
+// каждый пример — отдельная программа, поэтому он объявлен и здесь.
+type MultiError struct {
+	errs []error
+}
+
+// Добавление ошибки; nil игнорируется
+func (m *MultiError) Add(err error) {
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+}
+
+// Возвращает nil, если ошибок не было, иначе саму MultiError
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.errs), strings.Join(messages, "; "))
+}
+
+// errors.Is и errors.As проверяют каждую вложенную ошибку
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
+
+// Проверка, что в разобранном JSON есть все обязательные ключи.
+// Возвращает MultiError со всеми отсутствующими ключами, а не только с первым.
+func RequireKeys(m map[string]interface{}, keys ...string) error {
+	var errs MultiError
+	for _, key := range keys {
+		if _, ok := m[key]; !ok {
+			errs.Add(fmt.Errorf("missing key %q", key))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Поверхностная проверка типов значений по ключам; все несовпадения собираются в MultiError.
+// Учтите, что числа из JSON всегда имеют тип float64 (reflect.Float64).
+func RequireTypes(m map[string]interface{}, kinds map[string]reflect.Kind) error {
+	keys := make([]string, 0, len(kinds))
+	for key := range kinds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // Детерминированный порядок ошибок
+
+	var errs MultiError
+	for _, key := range keys {
+		value, ok := m[key]
+		if !ok {
+			errs.Add(fmt.Errorf("missing key %q", key))
+			continue
+		}
+		if actual := reflect.ValueOf(value).Kind(); actual != kinds[key] {
+			errs.Add(fmt.Errorf("key %q: expected %s, got %s", key, kinds[key], actual))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Разбор JSON с сохранением чисел как json.Number (исходный текст числа).
+// Обычный json.Unmarshal превращает числа в float64, и целые больше 2^53 теряют точность.
+// Как и json.Unmarshal, отклоняет данные после первого JSON-значения.
+func DecodeUseNumber(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var result map[string]interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON document")
+	}
+	return result, nil
+}
+
+// Приводит число из JSON к int64 без потери точности.
+// Поддерживает json.Number и float64 (если он целый и помещается в int64).
+func AsInt64(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		if err != nil {
+			return 0, err // ParseInt при переполнении возвращает границу диапазона
+		}
+		return i, nil
+	case float64:
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not representable as int64", n)
+		}
+		return int64(n), nil
+	default:
+		return 0, &TypeAssertionError{Expected: "number", Actual: fmt.Sprintf("%T", v)}
+	}
+}
+
+// Приводит число из JSON к float64; для больших целых возможна потеря точности
+func AsFloat64(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.Float64()
+	case float64:
+		return n, nil
+	default:
+		return 0, &TypeAssertionError{Expected: "number", Actual: fmt.Sprintf("%T", v)}
+	}
+}
+
+// Извлечение значения из разобранного JSON по пути через точку, например "users.0.name".
+// Для объектов сегмент — ключ (в том числе числовой, например "2024"),
+// для массивов — индекс. Пустой путь возвращает сам m.
+func GetPath(m map[string]interface{}, path string) (interface{}, error) {
+	var current interface{} = m
+	if path == "" {
+		return current, nil
+	}
+
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		at := strings.Join(segments[:i], ".") // Уже пройденная часть пути, для ошибок
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, fmt.Errorf("path %q: key %q not found at %q", path, segment, at)
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %q is not an array index at %q", path, segment, at)
+			}
+			if index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("path %q: index %d out of range [0, %d) at %q", path, index, len(node), at)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("path %q: cannot descend into %T at %q", path, current, at)
+		}
+	}
+	return current, nil
+}
+
 func main() {
 	// Примеры работы с пустым интерфейсом
 	var anything interface{}
@@ -65,4 +248,63 @@ func main() {
 	if active, ok := result["active"].(bool); ok {
 		fmt.Println("Active:", active)
 	}
+
+	// Type assertion с подробной ошибкой
+	if name, err := AssertType[string](result["name"]); err == nil {
+		fmt.Println("Asserted name:", name)
+	}
+	if _, err := AssertType[int](result["age"]); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// Проверка структуры JSON перед использованием
+	if err := RequireKeys(result, "name", "email", "role"); err != nil {
+		fmt.Println("Error:", err)
+	}
+	err := RequireTypes(result, map[string]reflect.Kind{
+		"name":   reflect.String,
+		"age":    reflect.String,
+		"active": reflect.Bool,
+	})
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// Извлечение вложенных значений по пути
+	var doc map[string]interface{}
+	nested := `{"users": [{"name": "alice", "tags": ["admin"]}, {"name": "bob"}], "stats": {"2024": 17}}`
+	if err := json.Unmarshal([]byte(nested), &doc); err != nil {
+		fmt.Println("Error parsing JSON:", err)
+		return
+	}
+	for _, path := range []string{"users.0.name", "users.0.tags.0", "stats.2024", "users.5.name", "users.1.email", "users.0.name.first"} {
+		value, err := GetPath(doc, path)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Println(path, "=", value)
+	}
+
+	// Большие целые: float64 теряет младшие разряды, json.Number — нет
+	payload := []byte(`{"id": 9007199254740993, "price": 19.99}`)
+	var lossy map[string]interface{}
+	if err := json.Unmarshal(payload, &lossy); err != nil {
+		fmt.Println("Error parsing JSON:", err)
+		return
+	}
+	fmt.Printf("ID via float64: %.0f\n", lossy["id"])
+
+	exact, err := DecodeUseNumber(payload)
+	if err != nil {
+		fmt.Println("Error parsing JSON:", err)
+		return
+	}
+	id, err := AsInt64(exact["id"])
+	fmt.Println("ID via json.Number:", id, err)
+	price, err := AsFloat64(exact["price"])
+	fmt.Println("Price:", price, err)
+	if _, err := AsInt64(exact["price"]); err != nil {
+		fmt.Println("Error:", err)
+	}
 }
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAssertType(t *testing.T) {
+	s, err := AssertType[string](interface{}("hello"))
+	if err != nil || s != "hello" {
+		t.Errorf("AssertType[string](\"hello\") = %q, %v, expected \"hello\", nil", s, err)
+	}
+
+	// Приведение к интерфейсу проходит, если значение его реализует
+	original := &TypeAssertionError{Expected: "int", Actual: "string"}
+	asError, err := AssertType[error](original)
+	if err != nil || asError != original {
+		t.Errorf("AssertType[error](%v) = %v, %v, expected the same value and nil", original, asError, err)
+	}
+}
+
+func TestAssertTypeError(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		assert   func(interface{}) error
+		expected string
+		actual   string
+	}{
+		{42, func(v interface{}) error { _, err := AssertType[string](v); return err }, "string", "int"},
+		{"42", func(v interface{}) error { _, err := AssertType[float64](v); return err }, "float64", "string"},
+		{nil, func(v interface{}) error { _, err := AssertType[int](v); return err }, "int", "<nil>"},
+		{[]int{1}, func(v interface{}) error { _, err := AssertType[error](v); return err }, "error", "[]int"},
+	}
+
+	for _, test := range tests {
+		err := test.assert(test.value)
+		var typeErr *TypeAssertionError
+		if !errors.As(err, &typeErr) {
+			t.Errorf("AssertType(%#v) error = %v, expected *TypeAssertionError", test.value, err)
+			continue
+		}
+		if typeErr.Expected != test.expected || typeErr.Actual != test.actual {
+			t.Errorf("AssertType(%#v) error = %+v, expected Expected %q, Actual %q", test.value, typeErr, test.expected, test.actual)
+		}
+		if msg := err.Error(); !strings.Contains(msg, test.expected) || !strings.Contains(msg, test.actual) {
+			t.Errorf("error message %q does not name both types", msg)
+		}
+	}
+}
+
+// Сообщения всех ошибок внутри MultiError
+func multiErrorMessages(t *testing.T, err error) []string {
+	t.Helper()
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("error %v (%T) is not a *MultiError", err, err)
+	}
+	var messages []string
+	for _, e := range multiErr.Unwrap() {
+		messages = append(messages, e.Error())
+	}
+	return messages
+}
+
+func TestRequireKeys(t *testing.T) {
+	payload := map[string]interface{}{"name": "alice", "age": 30.0}
+
+	if err := RequireKeys(payload, "name", "age"); err != nil {
+		t.Errorf("RequireKeys() with all keys present = %v, expected nil", err)
+	}
+
+	err := RequireKeys(payload, "name", "email", "role")
+	expected := []string{`missing key "email"`, `missing key "role"`}
+	if got := multiErrorMessages(t, err); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RequireKeys() errors = %v, expected %v", got, expected)
+	}
+	if !strings.HasPrefix(err.Error(), "2 errors occurred") {
+		t.Errorf("RequireKeys() message = %q, expected it to count both keys", err)
+	}
+}
+
+func TestRequireTypes(t *testing.T) {
+	payload := map[string]interface{}{"name": "alice", "age": "30", "tags": []interface{}{"a"}}
+	err := RequireTypes(payload, map[string]reflect.Kind{
+		"name":   reflect.String,
+		"age":    reflect.Float64,
+		"tags":   reflect.Slice,
+		"active": reflect.Bool,
+	})
+
+	expected := []string{`missing key "active"`, `key "age": expected float64, got string`}
+	if got := multiErrorMessages(t, err); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RequireTypes() errors = %v, expected %v", got, expected)
+	}
+
+	if err := RequireTypes(payload, map[string]reflect.Kind{"name": reflect.String}); err != nil {
+		t.Errorf("RequireTypes() with matching types = %v, expected nil", err)
+	}
+}
+
+func decodeDocument(t *testing.T) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	raw := `{"users": [{"name": "alice", "tags": ["admin"]}, {"name": "bob"}], "stats": {"2024": 17}, "count": 2}`
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestGetPath(t *testing.T) {
+	doc := decodeDocument(t)
+	tests := []struct {
+		path     string
+		expected interface{}
+	}{
+		{"users.0.name", "alice"},
+		{"users.1.name", "bob"},
+		{"users.0.tags.0", "admin"},
+		{"stats.2024", 17.0}, // Числовой ключ в объекте
+		{"count", 2.0},
+		{"users.0.tags", []interface{}{"admin"}},
+	}
+
+	for _, test := range tests {
+		got, err := GetPath(doc, test.path)
+		if err != nil || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("GetPath(%q) = %v, %v, expected %v, nil", test.path, got, err, test.expected)
+		}
+	}
+
+	if got, err := GetPath(doc, ""); err != nil || !reflect.DeepEqual(got, doc) {
+		t.Errorf("GetPath(\"\") = %v, %v, expected the document itself", got, err)
+	}
+}
+
+func TestGetPathErrors(t *testing.T) {
+	doc := decodeDocument(t)
+	tests := []struct {
+		path     string
+		contains string
+	}{
+		{"users.5.name", "index 5 out of range"},
+		{"users.-1", "index -1 out of range"},
+		{"users.first", "not an array index"},
+		{"users.1.email", `key "email" not found at "users.1"`},
+		{"missing", `key "missing" not found`},
+		{"users.0.name.first", "cannot descend into string"},
+		{"count.value", "cannot descend into float64"},
+	}
+
+	for _, test := range tests {
+		got, err := GetPath(doc, test.path)
+		if err == nil {
+			t.Errorf("GetPath(%q) = %v, nil, expected error", test.path, got)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.contains) {
+			t.Errorf("GetPath(%q) error = %q, expected it to contain %q", test.path, err, test.contains)
+		}
+	}
+}
+
+func TestDecodeUseNumberLargeInt(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected int64
+	}{
+		{`{"id": 9007199254740993}`, 9007199254740993}, // 2^53 + 1 — не представимо в float64
+		{`{"id": 9223372036854775807}`, math.MaxInt64},
+		{`{"id": -9223372036854775808}`, math.MinInt64},
+		{`{"id": 0}`, 0},
+	}
+
+	for _, test := range tests {
+		doc, err := DecodeUseNumber([]byte(test.raw))
+		if err != nil {
+			t.Fatalf("DecodeUseNumber(%s) returned error: %v", test.raw, err)
+		}
+		got, err := AsInt64(doc["id"])
+		if err != nil || got != test.expected {
+			t.Errorf("AsInt64 from %s = %d, %v, expected %d, nil", test.raw, got, err, test.expected)
+		}
+	}
+}
+
+func TestDecodeUseNumberInvalid(t *testing.T) {
+	for _, raw := range []string{``, `{"id":`, `[1, 2]`, `{"id": 1} {"id": 2}`, `{"id": 1} trailing`, `{"id": 1}]`} {
+		if doc, err := DecodeUseNumber([]byte(raw)); err == nil {
+			t.Errorf("DecodeUseNumber(%q) = %v, nil, expected error", raw, doc)
+		}
+	}
+}
+
+func TestDecodeUseNumberTrailingWhitespace(t *testing.T) {
+	doc, err := DecodeUseNumber([]byte("{\"id\": 1}\n\t "))
+	if err != nil || doc["id"] != json.Number("1") {
+		t.Errorf("DecodeUseNumber with trailing whitespace = %v, %v, expected map[id:1], nil", doc, err)
+	}
+}
+
+func TestAsInt64(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected int64
+		wantErr  bool
+	}{
+		{json.Number("42"), 42, false},
+		{json.Number("19.99"), 0, true},
+		{json.Number("9223372036854775808"), 0, true}, // MaxInt64 + 1
+		{float64(42), 42, false},
+		{-9223372036854775808.0, math.MinInt64, false},
+		{9223372036854775808.0, 0, true},
+		{19.99, 0, true},
+		{math.NaN(), 0, true},
+		{"42", 0, true},
+		{nil, 0, true},
+	}
+
+	for _, test := range tests {
+		got, err := AsInt64(test.value)
+		if (err != nil) != test.wantErr || got != test.expected {
+			t.Errorf("AsInt64(%#v) = %d, %v, expected %d (error: %v)", test.value, got, err, test.expected, test.wantErr)
+		}
+	}
+}
+
+func TestAsFloat64(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected float64
+		wantErr  bool
+	}{
+		{json.Number("19.99"), 19.99, false},
+		{json.Number("1e3"), 1000, false},
+		{2.5, 2.5, false},
+		{true, 0, true},
+	}
+
+	for _, test := range tests {
+		got, err := AsFloat64(test.value)
+		if (err != nil) != test.wantErr || got != test.expected {
+			t.Errorf("AsFloat64(%#v) = %v, %v, expected %v (error: %v)", test.value, got, err, test.expected, test.wantErr)
+		}
+	}
+
+	var typeErr *TypeAssertionError
+	if _, err := AsFloat64("x"); !errors.As(err, &typeErr) {
+		t.Errorf("AsFloat64(string) error = %v, expected *TypeAssertionError", err)
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"cmp"
 	"fmt"
+	"math"
 )
 
 // Обобщенная функция для работы с любым типом данных
@@ -27,6 +29,165 @@ func (b Box[T]) GetContent() T {
 	return b.content
 }
 
+// Двоичное дерево поиска
+type BinaryTree[T cmp.Ordered] struct {
+	root *treeNode[T]
+}
+
+type treeNode[T cmp.Ordered] struct {
+	value       T
+	left, right *treeNode[T]
+}
+
+// Добавляет значение; повторное значение игнорируется
+func (t *BinaryTree[T]) Insert(v T) {
+	link := &t.root
+	for *link != nil {
+		switch {
+		case v < (*link).value:
+			link = &(*link).left
+		case v > (*link).value:
+			link = &(*link).right
+		default:
+			return
+		}
+	}
+	*link = &treeNode[T]{value: v}
+}
+
+// Обход в прямом порядке (узел, затем левое и правое поддерево).
+// Если visit вернул false, поддеревья этого узла не посещаются.
+func (t *BinaryTree[T]) Walk(visit func(value T) (descend bool)) {
+	var walk func(n *treeNode[T])
+	walk = func(n *treeNode[T]) {
+		if n == nil || !visit(n.value) {
+			return
+		}
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(t.root)
+}
+
+// Разница между слайсами с семантикой множеств: added — элементы b, которых нет в a,
+// removed — элементы a, которых нет в b. Дубликаты учитываются один раз,
+// порядок результата — по первому появлению.
+func Diff[T comparable](a, b []T) (added, removed []T) {
+	inA := make(map[T]struct{}, len(a))
+	for _, v := range a {
+		inA[v] = struct{}{}
+	}
+	inB := make(map[T]struct{}, len(b))
+	for _, v := range b {
+		inB[v] = struct{}{}
+	}
+
+	seen := make(map[T]struct{})
+	for _, v := range b {
+		if _, ok := inA[v]; !ok {
+			if _, dup := seen[v]; !dup {
+				seen[v] = struct{}{}
+				added = append(added, v)
+			}
+		}
+	}
+	for _, v := range a {
+		if _, ok := inB[v]; !ok {
+			if _, dup := seen[v]; !dup {
+				seen[v] = struct{}{}
+				removed = append(removed, v)
+			}
+		}
+	}
+	return added, removed
+}
+
+// Удаляет повторы, сохраняя порядок первого появления
+func Distinct[T comparable](s []T) []T {
+	return DedupMerge(s, func(v T) T { return v }, func(a, _ T) T { return a })
+}
+
+// Схлопывает элементы с одинаковым ключом, сворачивая их через merge(накопленное, очередное).
+// Порядок результата — по первому появлению ключа; элементы с уникальным ключом
+// возвращаются без изменений (merge для них не вызывается).
+func DedupMerge[T any, K comparable](s []T, key func(T) K, merge func(a, b T) T) []T {
+	index := make(map[K]int, len(s))
+	var result []T
+	for _, v := range s {
+		k := key(v)
+		if i, ok := index[k]; ok {
+			result[i] = merge(result[i], v)
+			continue
+		}
+		index[k] = len(result)
+		result = append(result, v)
+	}
+	return result
+}
+
+// Поэлементное сравнение слайсов; слайсы разной длины не равны
+func SlicesEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Сравнение слайсов float64 с допуском eps: a[i] == b[i] или |a[i]-b[i]| <= eps.
+// Точное равенство проверяется первым: Inf-Inf дает NaN, и одинаковые бесконечности
+// иначе считались бы разными. NaN не равен ничему, в том числе другому NaN, как и при обычном ==.
+func FloatSlicesEqual(a, b []float64, eps float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.Abs(a[i]-b[i]) <= eps) {
+			return false
+		}
+	}
+	return true
+}
+
+// Накопитель статистики по потоку чисел без хранения самих значений.
+// Среднее и дисперсия считаются алгоритмом Уэлфорда, устойчивым к ошибкам округления.
+type Stats struct {
+	count    int
+	mean     float64
+	m2       float64 // Сумма квадратов отклонений от среднего
+	min, max float64
+}
+
+func (s *Stats) Add(x float64) {
+	s.count++
+	if s.count == 1 {
+		s.min, s.max = x, x
+	} else {
+		s.min = math.Min(s.min, x)
+		s.max = math.Max(s.max, x)
+	}
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+}
+
+func (s *Stats) Count() int    { return s.count }
+func (s *Stats) Mean() float64 { return s.mean }
+func (s *Stats) Min() float64  { return s.min }
+func (s *Stats) Max() float64  { return s.max }
+
+// Выборочная дисперсия; для менее чем двух значений возвращает 0
+func (s *Stats) Variance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count-1)
+}
+
 func main() {
 	// Использование обобщенной функции
 	PrintValue(42)
@@ -42,4 +203,62 @@ func main() {
 
 	fmt.Println("Box content (int):", intBox.GetContent())
 	fmt.Println("Box content (string):", stringBox.GetContent())
+
+	// Обход дерева с отсечением поддеревьев
+	var tree BinaryTree[int]
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80} {
+		tree.Insert(v)
+	}
+	var visited []int
+	tree.Walk(func(v int) bool {
+		visited = append(visited, v)
+		return v != 30 // Поддерево 30 (20 и 40) пропускаем
+	})
+	fmt.Println("Visited with pruning:", visited)
+	visited = nil
+	tree.Walk(func(v int) bool {
+		visited = append(visited, v)
+		return false
+	})
+	fmt.Println("Pruned at root:", visited)
+
+	// Сравнение состояний "до" и "после"
+	added, removed := Diff([]string{"read", "write", "write"}, []string{"read", "admin"})
+	fmt.Println("Added:", added, "Removed:", removed)
+
+	// Удаление повторов и объединение строк заказа по товару
+	fmt.Println("Distinct:", Distinct([]string{"go", "rust", "go", "zig", "rust"}))
+	type orderLine struct {
+		SKU    string
+		Amount int
+	}
+	lines := []orderLine{{"apple", 2}, {"pear", 1}, {"apple", 3}, {"plum", 4}, {"pear", 5}}
+	merged := DedupMerge(lines,
+		func(l orderLine) string { return l.SKU },
+		func(a, b orderLine) orderLine { return orderLine{SKU: a.SKU, Amount: a.Amount + b.Amount} },
+	)
+	fmt.Println("Merged order lines:", merged)
+
+	// Статистика по потоку против расчета по всему набору данных
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	var stats Stats
+	sum := 0.0
+	for _, x := range data {
+		stats.Add(x)
+		sum += x
+	}
+	mean := sum / float64(len(data))
+	squares := 0.0
+	for _, x := range data {
+		squares += (x - mean) * (x - mean)
+	}
+	fmt.Println("Streaming mean:", stats.Mean(), "Batch mean:", mean)
+	fmt.Println("Streaming variance:", stats.Variance(), "Batch variance:", squares/float64(len(data)-1))
+	fmt.Println("Min:", stats.Min(), "Max:", stats.Max())
+
+	// Сравнение вычисленных результатов с допуском
+	computed := []float64{0.1 + 0.2, 1.0 / 3.0}
+	fmt.Println("Exact equal:", SlicesEqual(computed, []float64{0.3, 0.333}))
+	fmt.Println("Equal within 1e-3:", FloatSlicesEqual(computed, []float64{0.3, 0.333}, 1e-3))
+	fmt.Println("Equal within 1e-4:", FloatSlicesEqual(computed, []float64{0.3, 0.333}, 1e-4))
 }
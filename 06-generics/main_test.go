@@ -0,0 +1,214 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		a, b           []int
+		added, removed []int
+	}{
+		{"overlapping", []int{1, 2, 3}, []int{2, 3, 4}, []int{4}, []int{1}},
+		{"disjoint", []int{1, 2}, []int{3, 4}, []int{3, 4}, []int{1, 2}},
+		{"identical", []int{1, 2, 3}, []int{1, 2, 3}, nil, nil},
+		{"same set in another order", []int{1, 2, 3}, []int{3, 1, 2}, nil, nil},
+		{"duplicates counted once", []int{1, 1, 2}, []int{2, 5, 5}, []int{5}, []int{1}},
+		{"empty a", nil, []int{1, 2}, []int{1, 2}, nil},
+		{"empty b", []int{1, 2}, nil, nil, []int{1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			added, removed := Diff(test.a, test.b)
+			if !reflect.DeepEqual(added, test.added) || !reflect.DeepEqual(removed, test.removed) {
+				t.Errorf("Diff(%v, %v) = %v, %v, expected %v, %v", test.a, test.b, added, removed, test.added, test.removed)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	// Пакетный расчет для сравнения
+	sum := 0.0
+	for _, x := range data {
+		sum += x
+	}
+	mean := sum / float64(len(data))
+	squares := 0.0
+	for _, x := range data {
+		squares += (x - mean) * (x - mean)
+	}
+	variance := squares / float64(len(data)-1)
+
+	var s Stats
+	for _, x := range data {
+		s.Add(x)
+	}
+	if s.Count() != len(data) {
+		t.Errorf("Count() = %d, expected %d", s.Count(), len(data))
+	}
+	if math.Abs(s.Mean()-mean) > 1e-9 {
+		t.Errorf("Mean() = %v, expected %v", s.Mean(), mean)
+	}
+	if math.Abs(s.Variance()-variance) > 1e-9 {
+		t.Errorf("Variance() = %v, expected %v", s.Variance(), variance)
+	}
+	if s.Min() != 2 || s.Max() != 9 {
+		t.Errorf("Min(), Max() = %v, %v, expected 2, 9", s.Min(), s.Max())
+	}
+}
+
+func TestStatsFewSamples(t *testing.T) {
+	var s Stats
+	if s.Variance() != 0 || s.Mean() != 0 {
+		t.Errorf("empty Stats: Mean() = %v, Variance() = %v, expected 0, 0", s.Mean(), s.Variance())
+	}
+	s.Add(-3)
+	if s.Variance() != 0 || s.Mean() != -3 || s.Min() != -3 || s.Max() != -3 {
+		t.Errorf("one sample: Mean() = %v, Variance() = %v, Min() = %v, Max() = %v, expected -3, 0, -3, -3",
+			s.Mean(), s.Variance(), s.Min(), s.Max())
+	}
+}
+
+func TestSlicesEqual(t *testing.T) {
+	tests := []struct {
+		a, b     []string
+		expected bool
+	}{
+		{nil, nil, true},
+		{nil, []string{}, true},
+		{[]string{"a", "b"}, []string{"a", "b"}, true},
+		{[]string{"a", "b"}, []string{"b", "a"}, false},
+		{[]string{"a"}, []string{"a", "b"}, false},
+	}
+
+	for _, test := range tests {
+		if got := SlicesEqual(test.a, test.b); got != test.expected {
+			t.Errorf("SlicesEqual(%q, %q) = %v, expected %v", test.a, test.b, got, test.expected)
+		}
+	}
+}
+
+func TestFloatSlicesEqual(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name     string
+		a, b     []float64
+		eps      float64
+		expected bool
+	}{
+		{"equal", []float64{1, 2}, []float64{1, 2}, 0, true},
+		{"within eps", []float64{1, 2}, []float64{1.05, 1.95}, 0.1, true},
+		{"exactly eps", []float64{1}, []float64{1.5}, 0.5, true},
+		{"just outside eps", []float64{1, 2}, []float64{1, 2.11}, 0.1, false},
+		{"different lengths", []float64{1, 2}, []float64{1}, 1, false},
+		{"NaN not equal to NaN", []float64{nan}, []float64{nan}, 1, false},
+		{"NaN not equal to number", []float64{nan}, []float64{1}, math.Inf(1), false},
+		{"equal +Inf", []float64{math.Inf(1)}, []float64{math.Inf(1)}, 0, true},
+		{"equal -Inf", []float64{1, math.Inf(-1)}, []float64{1, math.Inf(-1)}, 0.1, true},
+		{"opposite infinities", []float64{math.Inf(1)}, []float64{math.Inf(-1)}, math.MaxFloat64, false},
+		{"Inf and number", []float64{math.Inf(1)}, []float64{math.MaxFloat64}, 1, false},
+		{"Inf and NaN", []float64{math.Inf(1)}, []float64{nan}, 1, false},
+	}
+
+	for _, test := range tests {
+		if got := FloatSlicesEqual(test.a, test.b, test.eps); got != test.expected {
+			t.Errorf("%s: FloatSlicesEqual(%v, %v, %v) = %v, expected %v", test.name, test.a, test.b, test.eps, got, test.expected)
+		}
+	}
+}
+
+type orderLine struct {
+	ProductID int
+	Amount    int
+}
+
+func TestDedupMerge(t *testing.T) {
+	sum := func(a, b orderLine) orderLine {
+		return orderLine{ProductID: a.ProductID, Amount: a.Amount + b.Amount}
+	}
+	tests := []struct {
+		name     string
+		lines    []orderLine
+		expected []orderLine
+	}{
+		{"empty", nil, nil},
+		{"unique keys unchanged", []orderLine{{1, 2}, {2, 3}}, []orderLine{{1, 2}, {2, 3}}},
+		{"duplicates summed in first-seen order",
+			[]orderLine{{2, 1}, {1, 2}, {2, 3}, {3, 4}, {1, 5}, {2, 10}},
+			[]orderLine{{2, 14}, {1, 7}, {3, 4}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := DedupMerge(test.lines, func(l orderLine) int { return l.ProductID }, sum)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("DedupMerge(%v) = %v, expected %v", test.lines, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestDedupMergeSkipsMergeForUniqueKeys(t *testing.T) {
+	calls := 0
+	DedupMerge([]int{1, 2, 3}, func(v int) int { return v }, func(a, b int) int {
+		calls++
+		return a
+	})
+	if calls != 0 {
+		t.Errorf("merge called %d times for unique keys, expected 0", calls)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	got := Distinct([]string{"go", "rust", "go", "zig", "rust"})
+	if expected := []string{"go", "rust", "zig"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Distinct() = %q, expected %q", got, expected)
+	}
+}
+
+func TestBinaryTreeWalk(t *testing.T) {
+	var tree BinaryTree[int]
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 30} { // Повтор 30 игнорируется
+		tree.Insert(v)
+	}
+
+	tests := []struct {
+		name     string
+		prune    func(v int) bool
+		expected []int
+	}{
+		{"no pruning", func(int) bool { return false }, []int{50, 30, 20, 40, 70, 60, 80}},
+		{"prune root", func(v int) bool { return v == 50 }, []int{50}},
+		{"prune left subtree", func(v int) bool { return v == 30 }, []int{50, 30, 70, 60, 80}},
+		{"prune leaf", func(v int) bool { return v == 20 }, []int{50, 30, 20, 40, 70, 60, 80}},
+		{"prune both subtrees", func(v int) bool { return v == 30 || v == 70 }, []int{50, 30, 70}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var visited []int
+			tree.Walk(func(v int) bool {
+				visited = append(visited, v)
+				return !test.prune(v)
+			})
+			if !reflect.DeepEqual(visited, test.expected) {
+				t.Errorf("visited = %v, expected %v", visited, test.expected)
+			}
+		})
+	}
+}
+
+func TestBinaryTreeWalkEmpty(t *testing.T) {
+	var tree BinaryTree[string]
+	tree.Walk(func(v string) bool {
+		t.Errorf("visit called with %q on empty tree", v)
+		return true
+	})
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"sort"
 )
 
 // Определение интерфейса
@@ -53,6 +54,36 @@ func (m MultiFunctionDevice) Scan() {
 	fmt.Println("Scanning document...")
 }
 
+// Реестр плагинов с порядком выполнения: меньший priority выполняется раньше,
+// при равных приоритетах сохраняется порядок регистрации.
+// Обычно T — интерфейс (обработчик, middleware), и реестр не знает конкретных типов.
+type Registry[T any] struct {
+	entries []registryEntry[T]
+}
+
+type registryEntry[T any] struct {
+	priority int
+	item     T
+}
+
+func (r *Registry[T]) Register(priority int, item T) {
+	r.entries = append(r.entries, registryEntry[T]{priority: priority, item: item})
+}
+
+// Возвращает элементы в порядке приоритета; для пустого реестра — пустой слайс, не nil
+func (r *Registry[T]) Ordered() []T {
+	entries := append([]registryEntry[T](nil), r.entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority < entries[j].priority
+	})
+
+	items := make([]T, len(entries))
+	for i, e := range entries {
+		items[i] = e.item
+	}
+	return items
+}
+
 func main() {
 	// Использование интерфейсов
 	var s Speaker
@@ -76,4 +107,16 @@ func main() {
 
 	printer.Print()
 	scanner.Scan()
+
+	// Реестр: регистрируем в произвольном порядке, получаем по приоритету
+	var speakers Registry[Speaker]
+	speakers.Register(20, cat)
+	speakers.Register(10, dog)
+	speakers.Register(20, Dog{})
+	for _, sp := range speakers.Ordered() {
+		fmt.Println("Ordered speaker says:", sp.Speak())
+	}
+
+	var empty Registry[Printer]
+	fmt.Println("Empty registry:", len(empty.Ordered()), empty.Ordered() != nil)
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Плагин, который помнит свое имя, чтобы проверять порядок
+type namedPlugin string
+
+func (p namedPlugin) Speak() string { return string(p) }
+
+func TestRegistryOrdered(t *testing.T) {
+	var registry Registry[Speaker]
+	registry.Register(30, namedPlugin("auth"))
+	registry.Register(10, namedPlugin("logging"))
+	registry.Register(20, namedPlugin("metrics"))
+	registry.Register(10, namedPlugin("tracing")) // Равный приоритет — после logging
+	registry.Register(-5, namedPlugin("recover"))
+
+	var names []string
+	for _, p := range registry.Ordered() {
+		names = append(names, p.Speak())
+	}
+	expected := []string{"recover", "logging", "tracing", "metrics", "auth"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Ordered() = %q, expected %q", names, expected)
+	}
+}
+
+func TestRegistryOrderedDoesNotReorderEntries(t *testing.T) {
+	var registry Registry[int]
+	registry.Register(2, 1)
+	registry.Register(1, 2)
+	registry.Ordered()
+
+	// Регистрация после Ordered по-прежнему учитывает исходный порядок
+	registry.Register(1, 3)
+	if got := registry.Ordered(); !reflect.DeepEqual(got, []int{2, 3, 1}) {
+		t.Errorf("Ordered() = %v, expected [2 3 1]", got)
+	}
+}
+
+func TestRegistryEmpty(t *testing.T) {
+	var registry Registry[Speaker]
+	got := registry.Ordered()
+	if got == nil || len(got) != 0 {
+		t.Errorf("Ordered() on empty registry = %#v, expected empty non-nil slice", got)
+	}
+}
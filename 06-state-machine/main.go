@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Конечный автомат: состояния S и события E — любые сравнимые типы,
+// например перечисления на основе iota
+type StateMachine[S comparable, E comparable] struct {
+	current     S
+	transitions map[S]map[E]S
+}
+
+func NewStateMachine[S comparable, E comparable](initial S) *StateMachine[S, E] {
+	return &StateMachine[S, E]{
+		current:     initial,
+		transitions: make(map[S]map[E]S),
+	}
+}
+
+// Описывает переход: из состояния from по событию event в состояние to
+func (m *StateMachine[S, E]) AddTransition(from S, event E, to S) {
+	if m.transitions[from] == nil {
+		m.transitions[from] = make(map[E]S)
+	}
+	m.transitions[from][event] = to
+}
+
+// Выполняет переход по событию. Если перехода нет, возвращает ошибку
+// и оставляет автомат в текущем состоянии.
+func (m *StateMachine[S, E]) Fire(event E) error {
+	to, ok := m.transitions[m.current][event]
+	if !ok {
+		return fmt.Errorf("no transition from %v on event %v", m.current, event)
+	}
+	m.current = to
+	return nil
+}
+
+func (m *StateMachine[S, E]) Current() S {
+	return m.current
+}
+
+// Состояния светофора
+type Light int
+
+const (
+	Red Light = iota
+	Green
+	Yellow
+)
+
+func (l Light) String() string {
+	switch l {
+	case Red:
+		return "Red"
+	case Green:
+		return "Green"
+	case Yellow:
+		return "Yellow"
+	default:
+		return fmt.Sprintf("Light(%d)", int(l))
+	}
+}
+
+// События светофора
+type Signal string
+
+const (
+	Go       Signal = "go"
+	SlowDown Signal = "slow-down"
+	Stop     Signal = "stop"
+)
+
+func main() {
+	light := NewStateMachine[Light, Signal](Red)
+	light.AddTransition(Red, Go, Green)
+	light.AddTransition(Green, SlowDown, Yellow)
+	light.AddTransition(Yellow, Stop, Red)
+
+	// Полный цикл светофора
+	for _, signal := range []Signal{Go, SlowDown, Stop} {
+		if err := light.Fire(signal); err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Println("Light is now:", light.Current())
+	}
+
+	// Недопустимое событие: состояние не меняется
+	if err := light.Fire(Stop); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println("Light is still:", light.Current())
+}
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func newTrafficLight() *StateMachine[Light, Signal] {
+	light := NewStateMachine[Light, Signal](Red)
+	light.AddTransition(Red, Go, Green)
+	light.AddTransition(Green, SlowDown, Yellow)
+	light.AddTransition(Yellow, Stop, Red)
+	return light
+}
+
+func TestStateMachineCycle(t *testing.T) {
+	light := newTrafficLight()
+	tests := []struct {
+		signal   Signal
+		expected Light
+	}{
+		{Go, Green},
+		{SlowDown, Yellow},
+		{Stop, Red},
+		{Go, Green},
+	}
+
+	for _, test := range tests {
+		if err := light.Fire(test.signal); err != nil {
+			t.Fatalf("Fire(%v) = %v, expected nil", test.signal, err)
+		}
+		if got := light.Current(); got != test.expected {
+			t.Errorf("after Fire(%v) state = %v, expected %v", test.signal, got, test.expected)
+		}
+	}
+}
+
+func TestStateMachineInvalidEvent(t *testing.T) {
+	light := newTrafficLight()
+	for _, signal := range []Signal{Stop, SlowDown, Signal("unknown")} {
+		if err := light.Fire(signal); err == nil {
+			t.Errorf("Fire(%v) from Red = nil, expected an error", signal)
+		}
+		if got := light.Current(); got != Red {
+			t.Errorf("state after invalid Fire(%v) = %v, expected Red", signal, got)
+		}
+	}
+}
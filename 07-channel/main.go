@@ -2,9 +2,131 @@ package main
 
 import (
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 )
 
+// Кольцевой буфер фиксированной емкости: хранит последние N значений,
+// при переполнении перезаписывает самое старое. Безопасен для конкурентного использования.
+type RingBuffer[T any] struct {
+	mu    sync.Mutex
+	items []T
+	start int // индекс самого старого элемента
+	size  int
+}
+
+// Отрицательная емкость считается нулевой: такой буфер сразу вытесняет каждое значение
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	return &RingBuffer[T]{items: make([]T, max(capacity, 0))}
+}
+
+// Добавляет значение; если буфер был полон, возвращает вытесненный элемент
+func (r *RingBuffer[T]) Push(v T) (evicted T, didEvict bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.items) == 0 { // буфер нулевой емкости сразу вытесняет значение
+		return v, true
+	}
+	if r.size < len(r.items) {
+		r.items[(r.start+r.size)%len(r.items)] = v
+		r.size++
+		return evicted, false
+	}
+	evicted = r.items[r.start]
+	r.items[r.start] = v
+	r.start = (r.start + 1) % len(r.items)
+	return evicted, true
+}
+
+// Копия содержимого в хронологическом порядке (от старых к новым)
+func (r *RingBuffer[T]) Snapshot() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]T, r.size)
+	for i := 0; i < r.size; i++ {
+		result[i] = r.items[(r.start+i)%len(r.items)]
+	}
+	return result
+}
+
+// Рассылка значений всем слушателям: в отличие от обычного канала,
+// где каждое значение получает только один читатель, здесь его получает каждый.
+type Broadcaster[T any] struct {
+	mu        sync.Mutex
+	listeners []chan T
+	closed    bool
+}
+
+const listenerBufferSize = 16
+
+// Подписывает нового слушателя. Каждое сообщение — отдельное событие, поэтому
+// пропуск означает потерю: пока слушатель читает, он получает все сообщения, а буфер
+// на listenerBufferSize сообщений сглаживает короткие задержки. Слушатель, который
+// перестал читать и не вызвал Unlisten, не должен останавливать Send для всех
+// остальных — при заполненном буфере новые сообщения для него теряются.
+// После Close канал возвращается закрытым.
+func (b *Broadcaster[T]) Listen() <-chan T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan T, listenerBufferSize)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.listeners = append(b.listeners, ch)
+	return ch
+}
+
+// Отправляет значение всем текущим слушателям; после Close ничего не делает
+func (b *Broadcaster[T]) Send(v T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	for _, ch := range b.listeners {
+		select {
+		case ch <- v:
+		default: // Буфер слушателя полон: для него сообщение теряется
+		}
+	}
+}
+
+// Отписывает слушателя и закрывает его канал; непрочитанные сообщения в буфере
+// остаются доступны. Для неизвестного или уже отписанного канала ничего не делает.
+func (b *Broadcaster[T]) Unlisten(ch <-chan T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, listener := range b.listeners {
+		if listener == ch {
+			b.listeners = slices.Delete(b.listeners, i, i+1)
+			close(listener)
+			return
+		}
+	}
+}
+
+// Закрывает каналы всех слушателей
+func (b *Broadcaster[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, ch := range b.listeners {
+		close(ch)
+	}
+	b.listeners = nil
+}
+
 func main() {
 	// Основы каналов: что это, как они работают, зачем они нужны.
 	// Каналы в Go используются для обмена данными между горутинами.
@@ -60,6 +182,40 @@ func main() {
 		fmt.Printf("Основная горутина: получено сообщение: %s\n", msg)
 	}
 
+	// Пример с кольцевым буфером: храним только последние 3 события из канала.
+	events := make(chan string)
+	go func() {
+		for i := 1; i <= 5; i++ {
+			events <- fmt.Sprintf("Событие %d", i)
+		}
+		close(events)
+	}()
+
+	lastEvents := NewRingBuffer[string](3)
+	for event := range events {
+		if evicted, ok := lastEvents.Push(event); ok {
+			fmt.Println("Вытеснено из буфера:", evicted)
+		}
+	}
+	fmt.Println("Последние события:", lastEvents.Snapshot())
+
+	// Пример с рассылкой: каждое сообщение получают все слушатели.
+	var broadcaster Broadcaster[string]
+	var wg sync.WaitGroup
+	for i := 1; i <= 3; i++ {
+		listener := broadcaster.Listen()
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for msg := range listener {
+				fmt.Printf("Слушатель %d: получено сообщение: %s\n", id, msg)
+			}
+		}(i)
+	}
+	broadcaster.Send("Всем привет!")
+	broadcaster.Close()
+	wg.Wait()
+
 	// Пример с буферизированным каналом.
 	// Создаем буферизированный канал с емкостью 2.
 	bufferedCh := make(chan int, 2)
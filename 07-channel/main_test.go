@@ -0,0 +1,184 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRingBuffer(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		pushes   []int
+		evicted  []int
+		snapshot []int
+	}{
+		{"not full", 3, []int{1, 2}, nil, []int{1, 2}},
+		{"exactly full", 3, []int{1, 2, 3}, nil, []int{1, 2, 3}},
+		{"overflow", 3, []int{1, 2, 3, 4, 5}, []int{1, 2}, []int{3, 4, 5}},
+		{"wraps twice", 2, []int{1, 2, 3, 4, 5, 6}, []int{1, 2, 3, 4}, []int{5, 6}},
+		{"zero capacity", 0, []int{1, 2}, []int{1, 2}, []int{}},
+		{"negative capacity", -1, []int{1}, []int{1}, []int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRingBuffer[int](test.capacity)
+			var evicted []int
+			for _, v := range test.pushes {
+				if old, ok := r.Push(v); ok {
+					evicted = append(evicted, old)
+				}
+			}
+			if !reflect.DeepEqual(evicted, test.evicted) {
+				t.Errorf("evicted = %v, expected %v", evicted, test.evicted)
+			}
+			if got := r.Snapshot(); !reflect.DeepEqual(got, test.snapshot) {
+				t.Errorf("Snapshot() = %v, expected %v", got, test.snapshot)
+			}
+		})
+	}
+}
+
+func TestRingBufferSnapshotIsCopy(t *testing.T) {
+	r := NewRingBuffer[int](2)
+	r.Push(1)
+	snapshot := r.Snapshot()
+	snapshot[0] = 100
+	if got := r.Snapshot(); got[0] != 1 {
+		t.Errorf("Snapshot()[0] = %d after modifying previous snapshot, expected 1", got[0])
+	}
+}
+
+func TestRingBufferConcurrent(t *testing.T) {
+	const writers, perWriter, capacity = 8, 100, 10
+	r := NewRingBuffer[int](capacity)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	evictions := 0
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWriter; j++ {
+				if _, ok := r.Push(j); ok {
+					mu.Lock()
+					evictions++
+					mu.Unlock()
+				}
+				r.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(r.Snapshot()); got != capacity {
+		t.Errorf("len(Snapshot()) = %d, expected %d", got, capacity)
+	}
+	if expected := writers*perWriter - capacity; evictions != expected {
+		t.Errorf("evictions = %d, expected %d", evictions, expected)
+	}
+}
+
+func TestBroadcaster(t *testing.T) {
+	var b Broadcaster[string]
+	listeners := []<-chan string{b.Listen(), b.Listen(), b.Listen()}
+	b.Send("hello")
+	b.Send("world")
+	b.Close()
+
+	for i, ch := range listeners {
+		var got []string
+		for msg := range ch {
+			got = append(got, msg)
+		}
+		if !reflect.DeepEqual(got, []string{"hello", "world"}) {
+			t.Errorf("listener %d received %v, expected [hello world]", i, got)
+		}
+	}
+}
+
+func TestBroadcasterSlowListener(t *testing.T) {
+	var b Broadcaster[int]
+	slow := b.Listen() // Не читает до конца рассылки
+	fast := b.Listen()
+
+	// Быстрый слушатель вычитывает канал после каждой порции, поэтому его буфер
+	// никогда не переполняется и он должен получить все сообщения
+	const rounds = 3
+	fastCount := 0
+	for round := 0; round < rounds; round++ {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < listenerBufferSize; i++ {
+				b.Send(round*listenerBufferSize + i)
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Send blocked on a listener that stopped reading")
+		}
+		for i := 0; i < listenerBufferSize; i++ {
+			if v := <-fast; v != fastCount {
+				t.Fatalf("fast listener received %d, expected %d", v, fastCount)
+			}
+			fastCount++
+		}
+	}
+	b.Close()
+
+	if _, ok := <-fast; ok {
+		t.Error("fast listener received more values than were sent")
+	}
+	if fastCount != rounds*listenerBufferSize {
+		t.Errorf("fast listener received %d values, expected %d", fastCount, rounds*listenerBufferSize)
+	}
+	var slowValues []int
+	for v := range slow {
+		slowValues = append(slowValues, v)
+	}
+	if len(slowValues) != listenerBufferSize || slowValues[0] != 0 || slowValues[len(slowValues)-1] != listenerBufferSize-1 {
+		t.Errorf("slow listener received %v, expected the first %d values", slowValues, listenerBufferSize)
+	}
+}
+
+func TestBroadcasterUnlisten(t *testing.T) {
+	var b Broadcaster[string]
+	leaving := b.Listen()
+	staying := b.Listen()
+
+	b.Send("before")
+	b.Unlisten(leaving)
+	b.Unlisten(leaving) // Повторная отписка безопасна
+	b.Send("after")
+	b.Close()
+
+	var got []string
+	for msg := range leaving { // Канал закрыт, буфер дочитывается
+		got = append(got, msg)
+	}
+	if !reflect.DeepEqual(got, []string{"before"}) {
+		t.Errorf("unsubscribed listener received %v, expected [before]", got)
+	}
+	got = nil
+	for msg := range staying {
+		got = append(got, msg)
+	}
+	if !reflect.DeepEqual(got, []string{"before", "after"}) {
+		t.Errorf("remaining listener received %v, expected [before after]", got)
+	}
+}
+
+func TestBroadcasterListenAfterClose(t *testing.T) {
+	var b Broadcaster[int]
+	b.Close()
+	b.Send(1)
+	if _, ok := <-b.Listen(); ok {
+		t.Error("Listen() after Close returned an open channel")
+	}
+}
@@ -90,6 +90,39 @@ func exampleSyncBestPractices() {
 	fmt.Println("All tasks processed")
 }
 
+// Пример 5: Безопасное закрытие канала и отправка в закрытый канал
+// SafeClose перехватывает панику при повторном закрытии и сообщает, закрыл ли он канал.
+func SafeClose[T any](ch chan T) (closed bool) {
+	defer func() {
+		if recover() != nil {
+			closed = false
+		}
+	}()
+	close(ch)
+	return true
+}
+
+// SafeSend возвращает false вместо паники, если канал уже закрыт.
+// Пока канал открыт, отправка блокируется так же, как обычная ch <- v.
+func SafeSend[T any](ch chan T, v T) (sent bool) {
+	defer func() {
+		if recover() != nil {
+			sent = false
+		}
+	}()
+	ch <- v
+	return true
+}
+
+func exampleSafeClose() {
+	ch := make(chan int, 1)
+
+	fmt.Println("Send to open channel:", SafeSend(ch, 1))
+	fmt.Println("First close:", SafeClose(ch))
+	fmt.Println("Second close:", SafeClose(ch))
+	fmt.Println("Send to closed channel:", SafeSend(ch, 2))
+}
+
 func main() {
 	fmt.Println("Example 1: Data Race and Solution with Channels")
 	exampleDataRace()
@@ -102,4 +135,7 @@ func main() {
 
 	fmt.Println("\nExample 4: Best Practices for Goroutine Synchronization")
 	exampleSyncBestPractices()
+
+	fmt.Println("\nExample 5: Safe Close and Send on Channels")
+	exampleSafeClose()
 }
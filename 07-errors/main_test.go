@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSafeClose(t *testing.T) {
+	ch := make(chan int)
+	if !SafeClose(ch) {
+		t.Error("first SafeClose() = false, expected true")
+	}
+	if SafeClose(ch) {
+		t.Error("second SafeClose() = true, expected false")
+	}
+}
+
+func TestSafeCloseConcurrent(t *testing.T) {
+	ch := make(chan struct{})
+	var closed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if SafeClose(ch) {
+				closed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := closed.Load(); got != 1 {
+		t.Errorf("SafeClose reported closing %d times, expected 1", got)
+	}
+}
+
+func TestSafeSend(t *testing.T) {
+	ch := make(chan int, 1)
+	if !SafeSend(ch, 1) {
+		t.Error("SafeSend() to open channel = false, expected true")
+	}
+	if v := <-ch; v != 1 {
+		t.Errorf("received %d, expected 1", v)
+	}
+
+	close(ch)
+	if SafeSend(ch, 2) {
+		t.Error("SafeSend() to closed channel = true, expected false")
+	}
+}
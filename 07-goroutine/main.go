@@ -1,7 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"math/bits"
+	"sync"
 	"time"
 )
 
@@ -52,6 +56,311 @@ func example4() {
 	time.Sleep(1 * time.Second)
 }
 
+// Пример 5: Типизированный пул объектов поверх sync.Pool
+// Переиспользование объектов снижает нагрузку на сборщик мусора в горячих участках кода.
+type Pool[T any] struct {
+	pool sync.Pool
+}
+
+func NewPool[T any](newFn func() T) *Pool[T] {
+	return &Pool[T]{
+		pool: sync.Pool{New: func() any { return newFn() }},
+	}
+}
+
+// Возвращает объект из пула; если пул пуст, создает новый через newFn
+func (p *Pool[T]) Get() T {
+	return p.pool.Get().(T)
+}
+
+// Возвращает объект в пул для повторного использования
+func (p *Pool[T]) Put(v T) {
+	p.pool.Put(v)
+}
+
+func example5() {
+	buffers := NewPool(func() *bytes.Buffer { return new(bytes.Buffer) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			buf := buffers.Get()
+			defer buffers.Put(buf)
+
+			buf.Reset() // Объект из пула может содержать старые данные
+			fmt.Fprintf(buf, "goroutine %d used a pooled buffer", id)
+			fmt.Println(buf.String())
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Пример 6: Ленивые Map/Filter/Reduce над каналами
+// Каждая стадия запускает горутину, которая обрабатывает значения по мере поступления
+// и закрывает выходной канал, когда закрывается входной или отменяется ctx.
+// Отмена нужна, если потребитель перестает читать раньше: без нее горутина стадии
+// навсегда заблокируется на отправке.
+func MapChan[T, U any](ctx context.Context, in <-chan T, transform func(T) U) <-chan U {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- transform(v):
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func FilterChan[T any](ctx context.Context, in <-chan T, predicate func(T) bool) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				if !predicate(v) {
+					continue
+				}
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Блокируется до закрытия входного канала и возвращает накопленное значение
+func ReduceChan[T, U any](in <-chan T, accumulator func(U, T) U, initial U) U {
+	result := initial
+	for v := range in {
+		result = accumulator(result, v)
+	}
+	return result
+}
+
+// Мост между слайсами и каналами.
+// SliceToChan отправляет элементы в канал и закрывает его; для пустого или nil-слайса
+// канал закрывается сразу.
+func SliceToChan[T any](s []T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range s {
+			out <- v
+		}
+	}()
+	return out
+}
+
+// ChanToSlice читает канал до закрытия. nil-канал никогда не закрывается,
+// поэтому для него сразу возвращается nil, чтобы не заблокироваться навсегда.
+func ChanToSlice[T any](ch <-chan T) []T {
+	if ch == nil {
+		return nil
+	}
+	var result []T
+	for v := range ch {
+		result = append(result, v)
+	}
+	return result
+}
+
+func generate(count int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := 1; i <= count; i++ {
+			out <- i
+		}
+	}()
+	return out
+}
+
+func example6() {
+	// Квадраты чисел от 1 до 10, только четные, затем сумма
+	ctx := context.Background()
+	squares := MapChan(ctx, generate(10), func(x int) int { return x * x })
+	even := FilterChan(ctx, squares, func(x int) bool { return x%2 == 0 })
+	sum := ReduceChan(even, func(acc, x int) int { return acc + x }, 0)
+	fmt.Println("Sum of even squares:", sum)
+
+	// Те же стадии поверх обычного слайса
+	words := SliceToChan([]string{"go", "is", "fun"})
+	excited := MapChan(ctx, words, func(s string) string { return s + "!" })
+	fmt.Println("Collected:", ChanToSlice(excited))
+}
+
+// Пример 7: Пул байтовых буферов с классами размеров
+// Буферы хранятся в отдельных пулах по степеням двойки, поэтому буфер на 100 байт
+// не займет место буфера на мегабайт. Слишком большие буферы не пулятся,
+// чтобы один редкий огромный запрос не удерживал память.
+const (
+	minBufferClass = 6  // 64 байта
+	maxBufferClass = 20 // 1 МБ
+)
+
+type BufferPool struct {
+	classes [maxBufferClass - minBufferClass + 1]*Pool[*[]byte]
+	// sync.Pool хранит значения в interface{}, и положить туда слайс без аллокации нельзя.
+	// Поэтому буферы лежат в пулах классов по указателю, а сами указатели-обертки
+	// переиспользуются через отдельный пул и не выделяются при каждом Put.
+	holders *Pool[*[]byte]
+}
+
+func NewBufferPool() *BufferPool {
+	bp := &BufferPool{holders: NewPool(func() *[]byte { return new([]byte) })}
+	for i := range bp.classes {
+		size := 1 << (minBufferClass + i)
+		bp.classes[i] = NewPool(func() *[]byte {
+			buf := make([]byte, size)
+			return &buf
+		})
+	}
+	return bp
+}
+
+// Возвращает буфер длиной size с емкостью, округленной вверх до степени двойки.
+// Отрицательный size считается нулевым; для size больше максимального класса
+// буфер просто выделяется.
+func (bp *BufferPool) Get(size int) []byte {
+	size = max(size, 0)
+	class := bits.Len(uint(size - 1)) // Степень двойки, не меньшая size
+	if size == 0 {
+		class = 0
+	}
+	if class > maxBufferClass {
+		return make([]byte, size)
+	}
+	if class < minBufferClass {
+		class = minBufferClass
+	}
+	holder := bp.classes[class-minBufferClass].Get()
+	buf := (*holder)[:size]
+	*holder = nil // Обертка не должна удерживать буфер, пока лежит в пуле
+	bp.holders.Put(holder)
+	return buf
+}
+
+// Возвращает буфер в пул его класса. Буферы меньше минимального класса
+// или с емкостью больше максимального (включая nil) отбрасываются.
+func (bp *BufferPool) Put(buf []byte) {
+	if cap(buf) > 1<<maxBufferClass {
+		return
+	}
+	class := bits.Len(uint(cap(buf))) - 1 // Наибольший класс, который буфер вмещает целиком
+	if class < minBufferClass {
+		return
+	}
+	holder := bp.holders.Get()
+	*holder = buf[:1<<class]
+	bp.classes[class-minBufferClass].Put(holder)
+}
+
+func example7() {
+	pool := NewBufferPool()
+
+	buf := pool.Get(100)
+	fmt.Println("Requested 100 bytes, got len:", len(buf), "cap:", cap(buf))
+	first := &buf[0]
+	pool.Put(buf)
+
+	// Запрос из того же класса получает тот же буфер
+	buf = pool.Get(120)
+	fmt.Println("Reused within class:", &buf[0] == first)
+	pool.Put(buf)
+
+	// Большой буфер выделяется напрямую и в пул не возвращается
+	huge := pool.Get(4 << 20)
+	fmt.Println("Oversized buffer cap:", cap(huge))
+	pool.Put(huge)
+}
+
+// Пример 8: Future — результат фоновой работы, который можно забрать позже
+// Вычисление запускается сразу; Await можно вызывать сколько угодно раз
+// и из разных горутин — все получат один и тот же результат.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func Async[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(f.done) // Закрытие канала публикует value и err для всех ожидающих
+		f.value, f.err = fn()
+	}()
+	return f
+}
+
+// Ждет результат или отмену ctx. Отмена прерывает только ожидание,
+// само вычисление продолжается, и результат можно забрать следующим Await.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func example8() {
+	report := Async(func() (string, error) {
+		time.Sleep(100 * time.Millisecond) // Долгое вычисление
+		return "report ready", nil
+	})
+
+	// Нетерпеливый вызывающий не дожидается результата
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := report.Await(ctx)
+	fmt.Println("Impatient await:", err)
+
+	// Два ожидающих из разных горутин получают один результат
+	var wg sync.WaitGroup
+	for i := 1; i <= 2; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			value, err := report.Await(context.Background())
+			fmt.Println("Awaiter", id, "got:", value, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// Повторный Await после завершения возвращает результат сразу
+	value, _ := report.Await(context.Background())
+	fmt.Println("Cached result:", value)
+}
+
 func main() {
 	go sayHello()
 	time.Sleep(1 * time.Second)
@@ -62,5 +371,13 @@ func main() {
 
 	example4()
 
+	example5()
+
+	example6()
+
+	example7()
+
+	example8()
+
 	fmt.Println("Main function is finished.")
 }
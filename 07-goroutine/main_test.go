@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type connection struct {
+	id int
+}
+
+func TestPool(t *testing.T) {
+	created := 0
+	pool := NewPool(func() *connection {
+		created++
+		return &connection{id: created}
+	})
+
+	first := pool.Get()
+	if created != 1 || first == nil {
+		t.Fatalf("Get on empty pool: created = %d, value = %v, expected newFn to be called once", created, first)
+	}
+
+	pool.Put(first)
+	second := pool.Get()
+	if second == nil {
+		t.Fatal("Get after Put returned nil")
+	}
+	// sync.Pool не гарантирует переиспользование (например, после GC), поэтому только логируем
+	if second != first {
+		t.Logf("Put-then-Get returned a new instance (created = %d)", created)
+	}
+}
+
+func TestPoolValueType(t *testing.T) {
+	pool := NewPool(func() connection { return connection{id: 42} })
+	if c := pool.Get(); c.id != 42 {
+		t.Errorf("Get() = %+v, expected id 42", c)
+	}
+}
+
+func TestMapFilterReduceChan(t *testing.T) {
+	tests := []struct {
+		count    int
+		expected int
+	}{
+		{0, 0},
+		{1, 0},
+		{4, 4 + 16},
+		{10, 4 + 16 + 36 + 64 + 100},
+	}
+
+	for _, test := range tests {
+		ctx := context.Background()
+		squares := MapChan(ctx, generate(test.count), func(n int) int { return n * n })
+		even := FilterChan(ctx, squares, func(n int) bool { return n%2 == 0 })
+		sum := ReduceChan(even, func(acc, n int) int { return acc + n }, 0)
+		if sum != test.expected {
+			t.Errorf("sum of even squares up to %d = %d, expected %d", test.count, sum, test.expected)
+		}
+	}
+}
+
+func TestMapChanClosesOutput(t *testing.T) {
+	in := make(chan int)
+	ctx := context.Background()
+	out := FilterChan(ctx, MapChan(ctx, in, func(n int) int { return n }), func(int) bool { return true })
+	close(in)
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Error("received value from pipeline over closed empty input")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("downstream channel was not closed after closing input")
+	}
+}
+
+func TestChanPipelineNoLeaks(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		ctx := context.Background()
+		doubled := MapChan(ctx, generate(5), func(n int) int { return n * 2 })
+		ReduceChan(FilterChan(ctx, doubled, func(n int) bool { return n > 4 }), func(acc, n int) int { return acc + n }, 0)
+	}
+
+	// Горутины стадий завершаются асинхронно после закрытия своих каналов
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines: %d before, %d after", before, after)
+	}
+}
+
+func TestChanPipelineCancelEarly(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// Бесконечный источник: без отмены стадии заблокировались бы на отправке навсегда
+	source := make(chan int)
+	go func() {
+		defer close(source)
+		for i := 0; ; i++ {
+			select {
+			case source <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	doubled := MapChan(ctx, source, func(n int) int { return n * 2 })
+	out := FilterChan(ctx, doubled, func(n int) bool { return n%4 == 0 })
+	for i := 0; i < 3; i++ {
+		if v := <-out; v != i*4 {
+			t.Errorf("value %d = %d, expected %d", i, v, i*4)
+		}
+	}
+	cancel() // Перестаем читать, не дочитав канал
+
+	select {
+	case <-waitClosed(out):
+	case <-time.After(time.Second):
+		t.Fatal("output channel was not closed after cancel")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines: %d before, %d after cancel", before, after)
+	}
+}
+
+// Закрывается, когда ch закрыт; значения, успевшие пройти до отмены, отбрасываются
+func waitClosed[T any](ch <-chan T) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range ch {
+		}
+	}()
+	return done
+}
+
+func TestSliceToChanRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+	}{
+		{"nil slice", nil},
+		{"empty slice", []string{}},
+		{"single", []string{"go"}},
+		{"several", []string{"go", "is", "fun"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ChanToSlice(SliceToChan(test.input))
+			if len(got) != len(test.input) {
+				t.Fatalf("round trip of %q = %q", test.input, got)
+			}
+			for i := range got {
+				if got[i] != test.input[i] {
+					t.Errorf("round trip of %q = %q", test.input, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSliceToChanEmptyClosed(t *testing.T) {
+	select {
+	case _, ok := <-SliceToChan[int](nil):
+		if ok {
+			t.Error("received value from channel of nil slice")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel of nil slice was not closed")
+	}
+}
+
+func TestChanToSliceNil(t *testing.T) {
+	done := make(chan []int)
+	go func() { done <- ChanToSlice[int](nil) }()
+	select {
+	case got := <-done:
+		if got != nil {
+			t.Errorf("ChanToSlice(nil) = %v, expected nil", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ChanToSlice(nil) blocked")
+	}
+}
+
+func TestBufferPoolSizeClasses(t *testing.T) {
+	tests := []struct {
+		size        int
+		expectedLen int
+		expectedCap int
+	}{
+		{-5, 0, 64},
+		{0, 0, 64},
+		{1, 1, 64},
+		{64, 64, 64},
+		{65, 65, 128},
+		{1000, 1000, 1024},
+		{1 << 20, 1 << 20, 1 << 20},
+		{1<<20 + 1, 1<<20 + 1, 1<<20 + 1}, // Больше максимального класса — выделяется как есть
+	}
+
+	pool := NewBufferPool()
+	for _, test := range tests {
+		buf := pool.Get(test.size)
+		if len(buf) != test.expectedLen || cap(buf) != test.expectedCap {
+			t.Errorf("Get(%d): len = %d, cap = %d, expected len %d, cap %d",
+				test.size, len(buf), cap(buf), test.expectedLen, test.expectedCap)
+		}
+		pool.Put(buf)
+	}
+}
+
+func TestBufferPoolReuseWithinClass(t *testing.T) {
+	pool := NewBufferPool()
+	// sync.Pool может выбросить объект (GC, детектор гонок), поэтому даем несколько попыток
+	reused := false
+	for i := 0; i < 20 && !reused; i++ {
+		buf := pool.Get(100)
+		first := &buf[0]
+		pool.Put(buf)
+
+		buf = pool.Get(120)
+		reused = &buf[0] == first
+		if len(buf) != 120 {
+			t.Fatalf("Get(120) after Put returned len %d", len(buf))
+		}
+		pool.Put(buf)
+	}
+	if !reused {
+		t.Error("buffer was never reused within its size class")
+	}
+}
+
+func TestBufferPoolDiscardsOutOfRange(t *testing.T) {
+	pool := NewBufferPool()
+	huge := make([]byte, 4<<20)
+	pool.Put(huge)
+	// Емкость чуть больше максимального класса: буфер тоже не пулится,
+	// иначе пул удерживал бы лишние полмегабайта под видом буфера на 1 МБ
+	large := make([]byte, 1<<20+1<<19)
+	pool.Put(large)
+	tiny := make([]byte, 10)
+	pool.Put(tiny)
+	pool.Put(nil) // Не паникует
+
+	for _, size := range []int{1 << 20, 10} {
+		buf := pool.Get(size)
+		if c := cap(buf); c == cap(huge) || c == cap(large) || c == cap(tiny) {
+			t.Errorf("Get(%d) returned a buffer outside of its size class, cap = %d", size, c)
+		}
+	}
+}
+
+func TestBufferPoolGetPutDoesNotAllocate(t *testing.T) {
+	pool := NewBufferPool()
+	pool.Put(pool.Get(100)) // Прогрев: буфер и обертка уже в пуле
+	allocs := testing.AllocsPerRun(100, func() {
+		buf := pool.Get(100)
+		pool.Put(buf)
+	})
+	// Редкие аллокации возможны при росте внутренней очереди sync.Pool
+	if allocs >= 1 {
+		t.Errorf("Get/Put allocates %.2f times per pair, expected no allocations", allocs)
+	}
+}
+
+func TestFutureAwaitFromTwoGoroutines(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	future := Async(func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := future.Await(context.Background())
+			if err != nil {
+				t.Errorf("Await() returned error: %v", err)
+			}
+			results[i] = v
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	if results[0] != 42 || results[1] != 42 {
+		t.Errorf("Await results = %v, expected [42 42]", results)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("fn called %d times, expected 1", calls.Load())
+	}
+}
+
+func TestFutureAwaitAfterCompletion(t *testing.T) {
+	errFailed := errors.New("failed")
+	future := Async(func() (string, error) { return "partial", errFailed })
+	future.Await(context.Background())
+
+	// Завершенный Future отдает результат даже с уже отмененным контекстом
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		v, err := future.Await(ctx)
+		if v != "partial" || !errors.Is(err, errFailed) {
+			t.Errorf("Await() #%d = %q, %v, expected \"partial\", %v", i, v, err, errFailed)
+		}
+	}
+}
+
+func TestFutureAwaitCancelled(t *testing.T) {
+	release := make(chan struct{})
+	future := Async(func() (int, error) {
+		<-release
+		return 7, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if v, err := future.Await(ctx); v != 0 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Await() with expired ctx = %d, %v, expected 0, %v", v, err, context.DeadlineExceeded)
+	}
+
+	// Отмена прервала только ожидание: результат можно забрать позже
+	close(release)
+	if v, err := future.Await(context.Background()); v != 7 || err != nil {
+		t.Errorf("Await() after cancelled wait = %d, %v, expected 7, nil", v, err)
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Очередь с приоритетами на основе min-heap.
+// Порядок задается функцией less; элементы с равным приоритетом выходят в порядке добавления (FIFO).
+type PriorityQueue[T any] struct {
+	items itemHeap[T]
+	seq   uint64
+}
+
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{items: itemHeap[T]{less: less}}
+}
+
+func (q *PriorityQueue[T]) Push(v T) {
+	heap.Push(&q.items, queueItem[T]{value: v, seq: q.seq})
+	q.seq++
+}
+
+// Извлекает элемент с наивысшим приоритетом; false, если очередь пуста
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	if q.items.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(&q.items).(queueItem[T]).value, true
+}
+
+// Возвращает элемент с наивысшим приоритетом, не извлекая его
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if q.items.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items.data[0].value, true
+}
+
+func (q *PriorityQueue[T]) Len() int {
+	return q.items.Len()
+}
+
+type queueItem[T any] struct {
+	value T
+	seq   uint64 // порядковый номер для стабильности при равных приоритетах
+}
+
+// Реализация heap.Interface
+type itemHeap[T any] struct {
+	data []queueItem[T]
+	less func(a, b T) bool
+}
+
+func (h itemHeap[T]) Len() int { return len(h.data) }
+
+func (h itemHeap[T]) Less(i, j int) bool {
+	a, b := h.data[i], h.data[j]
+	if h.less(a.value, b.value) {
+		return true
+	}
+	if h.less(b.value, a.value) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (h itemHeap[T]) Swap(i, j int) { h.data[i], h.data[j] = h.data[j], h.data[i] }
+
+func (h *itemHeap[T]) Push(x any) { h.data = append(h.data, x.(queueItem[T])) }
+
+func (h *itemHeap[T]) Pop() any {
+	last := h.data[len(h.data)-1]
+	h.data = h.data[:len(h.data)-1]
+	return last
+}
+
+// K-way слияние уже отсортированных слайсов в один отсортированный.
+// В куче хранится по одному текущему элементу из каждого слайса.
+// Равные элементы идут в порядке номеров входных слайсов.
+func MergeSorted[T any](less func(a, b T) bool, slices ...[]T) []T {
+	if len(slices) == 1 {
+		return slices[0]
+	}
+
+	type cursor struct {
+		source, index int
+	}
+	value := func(c cursor) T { return slices[c.source][c.index] }
+
+	total := 0
+	pq := NewPriorityQueue(func(a, b cursor) bool {
+		if less(value(a), value(b)) {
+			return true
+		}
+		if less(value(b), value(a)) {
+			return false
+		}
+		return a.source < b.source
+	})
+	for i, s := range slices {
+		total += len(s)
+		if len(s) > 0 {
+			pq.Push(cursor{source: i})
+		}
+	}
+
+	result := make([]T, 0, total)
+	for {
+		c, ok := pq.Pop()
+		if !ok {
+			return result
+		}
+		result = append(result, value(c))
+		if c.index+1 < len(slices[c.source]) {
+			pq.Push(cursor{source: c.source, index: c.index + 1})
+		}
+	}
+}
+
+// k наибольших элементов за O(n log k), отсортированных по убыванию.
+// Куча хранит не больше k элементов, а ее вершина — наименьший из них:
+// очередной элемент заменяет вершину, только если он больше.
+func TopK[T any](items []T, k int, less func(a, b T) bool) []T {
+	if k <= 0 {
+		return []T{}
+	}
+
+	pq := NewPriorityQueue(less)
+	for _, v := range items {
+		if pq.Len() < k {
+			pq.Push(v)
+			continue
+		}
+		if smallest, _ := pq.Peek(); less(smallest, v) {
+			pq.Pop()
+			pq.Push(v)
+		}
+	}
+
+	result := make([]T, pq.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i], _ = pq.Pop()
+	}
+	return result
+}
+
+var ErrQueueFull = errors.New("job queue is full")
+var ErrDispatcherClosed = errors.New("dispatcher is closed")
+
+// Фоновая задача: меньшее значение Priority означает более высокий приоритет
+type Job struct {
+	Name     string
+	Priority int
+	Run      func()
+}
+
+// Диспетчер: ограниченная очередь задач, которые выполняет пул воркеров
+// в порядке приоритета.
+type Dispatcher struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    *PriorityQueue[Job]
+	capacity int
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+func NewDispatcher(capacity int) *Dispatcher {
+	d := &Dispatcher{
+		queue: NewPriorityQueue(func(a, b Job) bool {
+			return a.Priority < b.Priority
+		}),
+		capacity: capacity,
+	}
+	d.cond = sync.NewCond(&d.mu)
+	return d
+}
+
+// Добавляет задачу; возвращает ErrQueueFull, если очередь заполнена
+func (d *Dispatcher) Submit(job Job) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+	if d.queue.Len() >= d.capacity {
+		return ErrQueueFull
+	}
+	d.queue.Push(job)
+	d.cond.Signal()
+	return nil
+}
+
+// Запускает воркеры, которые забирают задачи с наивысшим приоритетом
+func (d *Dispatcher) Start(workers int) {
+	for i := 0; i < workers; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+}
+
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+	for {
+		d.mu.Lock()
+		for d.queue.Len() == 0 && !d.closed {
+			d.cond.Wait()
+		}
+		job, ok := d.queue.Pop()
+		d.mu.Unlock()
+
+		if !ok { // очередь пуста и диспетчер закрыт
+			return
+		}
+		job.Run()
+	}
+}
+
+// Прекращает прием задач и ждет, пока воркеры выполнят оставшиеся
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	d.closed = true
+	d.cond.Broadcast()
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+func main() {
+	// Очередь с приоритетами сама по себе
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		pq.Push(v)
+	}
+	for pq.Len() > 0 {
+		v, _ := pq.Pop()
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+
+	// Слияние отсортированных потоков логов по времени
+	merged := MergeSorted(func(a, b int) bool { return a < b },
+		[]int{1, 4, 9},
+		[]int{},
+		[]int{2, 3, 10},
+		[]int{5, 6, 7, 8},
+	)
+	fmt.Println("Merged:", merged)
+
+	// Топ-3 самых медленных запросов без полной сортировки
+	latencies := []int{120, 35, 980, 45, 300, 980, 15, 610}
+	fmt.Println("Top 3 latencies:", TopK(latencies, 3, func(a, b int) bool { return a < b }))
+	fmt.Println("Top 20 latencies:", TopK(latencies, 20, func(a, b int) bool { return a < b }))
+
+	// Диспетчер с одним воркером: порядок выполнения определяется приоритетом,
+	// задачи с равным приоритетом выполняются в порядке добавления
+	dispatcher := NewDispatcher(5)
+	jobs := []Job{
+		{Name: "send-report", Priority: 3},
+		{Name: "charge-card", Priority: 1},
+		{Name: "resize-image", Priority: 2},
+		{Name: "refund", Priority: 1},
+		{Name: "cleanup", Priority: 3},
+		{Name: "reindex", Priority: 2},
+	}
+	for _, job := range jobs {
+		name := job.Name
+		job.Run = func() { fmt.Println("Running job:", name) }
+		if err := dispatcher.Submit(job); err != nil {
+			fmt.Println("Submit", name, "failed:", err)
+		}
+	}
+
+	dispatcher.Start(1)
+	dispatcher.Close()
+}
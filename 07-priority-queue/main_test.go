@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestPriorityQueueStable(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	pq := NewPriorityQueue(func(a, b task) bool { return a.priority < b.priority })
+	for _, v := range []task{{"a", 2}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 0}, {"f", 2}} {
+		pq.Push(v)
+	}
+
+	if pq.Len() != 6 {
+		t.Errorf("Len() = %d, expected 6", pq.Len())
+	}
+	var order []string
+	for v, ok := pq.Pop(); ok; v, ok = pq.Pop() {
+		order = append(order, v.name)
+	}
+	expected := []string{"e", "b", "d", "a", "c", "f"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("pop order = %v, expected %v", order, expected)
+	}
+	if pq.Len() != 0 {
+		t.Errorf("Len() after draining = %d, expected 0", pq.Len())
+	}
+	if _, ok := pq.Pop(); ok {
+		t.Error("Pop() on empty queue returned true")
+	}
+}
+
+func TestDispatcherOrder(t *testing.T) {
+	jobs := []Job{
+		{Name: "low-1", Priority: 3},
+		{Name: "high-1", Priority: 1},
+		{Name: "mid-1", Priority: 2},
+		{Name: "high-2", Priority: 1},
+		{Name: "low-2", Priority: 3},
+		{Name: "mid-2", Priority: 2},
+	}
+
+	d := NewDispatcher(len(jobs))
+	var mu sync.Mutex
+	var order []string
+	for _, job := range jobs {
+		name := job.Name
+		job.Run = func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+		if err := d.Submit(job); err != nil {
+			t.Fatalf("Submit(%s) = %v, expected nil", name, err)
+		}
+	}
+	// Один воркер, запущенный после постановки всех задач, выполняет их строго по приоритету
+	d.Start(1)
+	d.Close()
+
+	expected := []string{"high-1", "high-2", "mid-1", "mid-2", "low-1", "low-2"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("execution order = %v, expected %v", order, expected)
+	}
+}
+
+func TestDispatcherSubmitErrors(t *testing.T) {
+	d := NewDispatcher(1)
+	noop := func() {}
+	if err := d.Submit(Job{Name: "first", Run: noop}); err != nil {
+		t.Fatalf("Submit(first) = %v, expected nil", err)
+	}
+	if err := d.Submit(Job{Name: "second", Run: noop}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Submit to full queue = %v, expected %v", err, ErrQueueFull)
+	}
+
+	d.Start(2)
+	d.Close()
+	if err := d.Submit(Job{Name: "late", Run: noop}); !errors.Is(err, ErrDispatcherClosed) {
+		t.Errorf("Submit after Close = %v, expected %v", err, ErrDispatcherClosed)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	tests := []struct {
+		name     string
+		slices   [][]int
+		expected []int
+	}{
+		{"three slices", [][]int{{1, 4, 9}, {2, 3, 10}, {5, 6, 7, 8}}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{"with empty", [][]int{{}, {3, 5}, nil, {1, 4}}, []int{1, 3, 4, 5}},
+		{"duplicates", [][]int{{1, 2, 2}, {2, 3}}, []int{1, 2, 2, 2, 3}},
+		{"all empty", [][]int{{}, nil}, []int{}},
+		{"no slices", nil, []int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MergeSorted(less, test.slices...); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("MergeSorted(%v) = %v, expected %v", test.slices, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestMergeSortedSingleInput(t *testing.T) {
+	input := []int{1, 2, 3}
+	got := MergeSorted(func(a, b int) bool { return a < b }, input)
+	if &got[0] != &input[0] {
+		t.Error("MergeSorted with a single input did not return it as-is")
+	}
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	type entry struct {
+		time   int
+		source string
+	}
+	byTime := func(a, b entry) bool { return a.time < b.time }
+	got := MergeSorted(byTime,
+		[]entry{{1, "a"}, {2, "a"}},
+		[]entry{{1, "b"}, {2, "b"}},
+	)
+	expected := []entry{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("MergeSorted() = %v, expected %v", got, expected)
+	}
+}
+
+func TestTopKAgainstSort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		data := make([]int, r.Intn(50))
+		for j := range data {
+			data[j] = r.Intn(20) // Много повторов
+		}
+		k := r.Intn(60)
+
+		sorted := append([]int(nil), data...)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		expected := append([]int{}, sorted[:min(k, len(sorted))]...)
+
+		if got := TopK(data, k, less); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("TopK(%v, %d) = %v, expected %v", data, k, got, expected)
+		}
+	}
+}
+
+func TestTopKEdgeCases(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	data := []int{120, 35, 980, 45}
+	tests := []struct {
+		k        int
+		expected []int
+	}{
+		{-1, []int{}},
+		{0, []int{}},
+		{1, []int{980}},
+		{4, []int{980, 120, 45, 35}},
+		{10, []int{980, 120, 45, 35}},
+	}
+
+	for _, test := range tests {
+		if got := TopK(data, test.k, less); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("TopK(%v, %d) = %v, expected %v", data, test.k, got, test.expected)
+		}
+	}
+	if got := TopK(nil, 3, less); len(got) != 0 {
+		t.Errorf("TopK(nil, 3) = %v, expected empty", got)
+	}
+	if !reflect.DeepEqual(data, []int{120, 35, 980, 45}) {
+		t.Errorf("TopK modified input: %v", data)
+	}
+}
+
+func TestPriorityQueuePeek(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	if _, ok := pq.Peek(); ok {
+		t.Error("Peek() on empty queue returned ok")
+	}
+	for _, v := range []int{3, 1, 2} {
+		pq.Push(v)
+	}
+	if v, ok := pq.Peek(); !ok || v != 1 || pq.Len() != 3 {
+		t.Errorf("Peek() = %d, %v with Len %d, expected 1, true without removing", v, ok, pq.Len())
+	}
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -14,6 +17,12 @@ func main() {
 
 	// Пример 3: Использование select с несколькими каналами и тайм-аутами
 	exampleMultipleChannelsWithTimeout()
+
+	// Пример 4: Группировка элементов в пачки по размеру или по времени
+	exampleBatcher()
+
+	// Пример 5: Вычитывание канала с ограничением по времени
+	exampleDrain()
 }
 
 // Пример 1: Объяснение конструкции select
@@ -94,3 +103,203 @@ func exampleMultipleChannelsWithTimeout() {
 	// В этом примере select выберет данные из ch1, так как он ответит первым.
 	// Если бы ch1 ответил позже 2 секунд, сработал бы тайм-аут.
 }
+
+// Пример 4: Группировка элементов в пачки по размеру или по времени
+// Пачка отправляется в flush, когда набралось maxSize элементов или прошло maxWait
+// с момента добавления первого элемента пачки. flush вызывается вне мьютекса, поэтому
+// медленный flush не блокирует Add, но вызовы flush идут последовательно и в порядке
+// пачек. flush не должен вызывать Add того же Batcher.
+var ErrBatcherClosed = errors.New("batcher is closed")
+
+type Batcher[T any] struct {
+	mu        sync.Mutex
+	delivered *sync.Cond // Сигнал о завершении очередного вызова flush
+	items     []T
+	maxSize   int
+	maxWait   time.Duration
+	flush     func([]T)
+	timer     *time.Timer
+	batchID   int // Номер текущей пачки, чтобы таймер старой пачки не сбросил новую
+	closed    bool
+	queued    int // Сколько пачек забрано на отправку
+	flushed   int // Сколько из них уже обработал flush
+}
+
+func NewBatcher[T any](maxSize int, maxWait time.Duration, flush func([]T)) *Batcher[T] {
+	b := &Batcher[T]{maxSize: maxSize, maxWait: maxWait, flush: flush}
+	b.delivered = sync.NewCond(&b.mu)
+	return b
+}
+
+// Добавляет элемент; после Close возвращает ErrBatcherClosed
+func (b *Batcher[T]) Add(item T) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+
+	b.items = append(b.items, item)
+	if len(b.items) == 1 {
+		id := b.batchID
+		b.timer = time.AfterFunc(b.maxWait, func() {
+			b.mu.Lock()
+			if b.batchID != id {
+				b.mu.Unlock()
+				return
+			}
+			batch, seq := b.takeLocked()
+			b.mu.Unlock()
+			b.deliver(batch, seq)
+		})
+	}
+	if len(b.items) < b.maxSize {
+		b.mu.Unlock()
+		return nil
+	}
+	batch, seq := b.takeLocked()
+	b.mu.Unlock()
+	b.deliver(batch, seq)
+	return nil
+}
+
+// Отправляет оставшиеся элементы и ждет завершения всех вызовов flush.
+// Повторный вызов ничего не делает.
+func (b *Batcher[T]) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	batch, seq := b.takeLocked()
+	b.mu.Unlock()
+	b.deliver(batch, seq)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.flushed != b.queued { // Пачка, забранная таймером, может еще отправляться
+		b.delivered.Wait()
+	}
+}
+
+// Забирает текущую пачку и возвращает ее номер в очереди отправки
+func (b *Batcher[T]) takeLocked() ([]T, int) {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.batchID++
+	if len(b.items) == 0 {
+		return nil, 0
+	}
+	batch := b.items
+	b.items = nil
+	seq := b.queued
+	b.queued++
+	return batch, seq
+}
+
+// Вызывает flush без мьютекса, дождавшись отправки всех предыдущих пачек
+func (b *Batcher[T]) deliver(batch []T, seq int) {
+	if batch == nil {
+		return
+	}
+
+	b.mu.Lock()
+	for b.flushed != seq {
+		b.delivered.Wait()
+	}
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.flushed++
+		b.delivered.Broadcast()
+		b.mu.Unlock()
+	}()
+	b.flush(batch)
+}
+
+func exampleBatcher() {
+	fmt.Println("\n--- Пример 4: Batcher ---")
+
+	batcher := NewBatcher(3, 200*time.Millisecond, func(batch []int) {
+		fmt.Println("Запись пачки:", batch)
+	})
+
+	// Пачки по размеру
+	for i := 1; i <= 4; i++ {
+		batcher.Add(i)
+	}
+
+	// Элемент 4 отправится по таймеру
+	time.Sleep(300 * time.Millisecond)
+
+	// Оставшиеся элементы отправятся при закрытии
+	batcher.Add(5)
+	batcher.Close()
+}
+
+// Пример 5: Вычитывание канала с ограничением по времени
+// Собирает значения из ch, пока канал не закрыт, не истек timeout или не отменен ctx.
+// Собранное возвращается в любом случае. Ошибка nil означает, что канал закрыт
+// и вычитан полностью; иначе — context.DeadlineExceeded или причина отмены ctx.
+// При timeout <= 0 забирается только то, что доступно сразу, без ожидания.
+func Drain[T any](ctx context.Context, ch <-chan T, timeout time.Duration) ([]T, error) {
+	var items []T
+	if timeout <= 0 {
+		// Уже истекший контекст в select ниже конкурировал бы с готовыми значениями
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					return items, nil
+				}
+				items = append(items, v)
+			default:
+				if err := ctx.Err(); err != nil {
+					return items, err
+				}
+				return items, context.DeadlineExceeded
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return items, nil
+			}
+			items = append(items, v)
+		case <-ctx.Done():
+			return items, ctx.Err()
+		}
+	}
+}
+
+func exampleDrain() {
+	fmt.Println("\n--- Пример 5: Drain ---")
+
+	// Канал закрывается — получаем все значения без ошибки
+	closed := make(chan int, 5)
+	for i := 1; i <= 3; i++ {
+		closed <- i
+	}
+	close(closed)
+	items, err := Drain(context.Background(), closed, time.Second)
+	fmt.Println("Закрытый канал:", items, err)
+
+	// Канал никогда не закрывается — возвращаем то, что успели собрать
+	events := make(chan string)
+	go func() {
+		for _, e := range []string{"login", "click"} {
+			events <- e
+		}
+	}()
+	pending, err := Drain(context.Background(), events, 100*time.Millisecond)
+	fmt.Println("Незакрытый канал:", pending, err)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Собирает пачки, переданные во flush
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *batchRecorder) flush(batch []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, batch)
+}
+
+func (r *batchRecorder) get() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]int(nil), r.batches...)
+}
+
+func TestBatcherFlushBySize(t *testing.T) {
+	var r batchRecorder
+	b := NewBatcher(3, time.Hour, r.flush)
+	for i := 1; i <= 7; i++ {
+		b.Add(i)
+	}
+	expected := [][]int{{1, 2, 3}, {4, 5, 6}}
+	if got := r.get(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("batches before Close = %v, expected %v", got, expected)
+	}
+
+	b.Close()
+	expected = append(expected, []int{7})
+	if got := r.get(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("batches after Close = %v, expected %v", got, expected)
+	}
+}
+
+func TestBatcherFlushByTime(t *testing.T) {
+	var r batchRecorder
+	b := NewBatcher(10, 20*time.Millisecond, r.flush)
+	b.Add(1)
+	b.Add(2)
+
+	deadline := time.Now().Add(time.Second)
+	for len(r.get()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := r.get(); !reflect.DeepEqual(got, [][]int{{1, 2}}) {
+		t.Fatalf("batches after maxWait = %v, expected [[1 2]]", got)
+	}
+
+	// Таймер новой пачки отсчитывается от ее первого элемента
+	b.Add(3)
+	b.Close()
+	if got := r.get(); !reflect.DeepEqual(got, [][]int{{1, 2}, {3}}) {
+		t.Errorf("batches after Close = %v, expected [[1 2] [3]]", got)
+	}
+}
+
+func TestBatcherCloseEmpty(t *testing.T) {
+	var r batchRecorder
+	b := NewBatcher(3, time.Millisecond, r.flush)
+	b.Close()
+	if got := r.get(); len(got) != 0 {
+		t.Errorf("Close on empty batcher flushed %v, expected nothing", got)
+	}
+}
+
+func TestBatcherAddAfterClose(t *testing.T) {
+	var r batchRecorder
+	b := NewBatcher(3, time.Hour, r.flush)
+	if err := b.Add(1); err != nil {
+		t.Fatalf("Add before Close = %v, expected nil", err)
+	}
+	b.Close()
+	b.Close() // Повторный Close безопасен
+
+	if err := b.Add(2); !errors.Is(err, ErrBatcherClosed) {
+		t.Errorf("Add after Close = %v, expected %v", err, ErrBatcherClosed)
+	}
+	if got := r.get(); !reflect.DeepEqual(got, [][]int{{1}}) {
+		t.Errorf("batches = %v, expected [[1]]", got)
+	}
+}
+
+func TestBatcherSlowFlushDoesNotBlockAdd(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var r batchRecorder
+	b := NewBatcher(2, time.Hour, func(batch []int) {
+		started <- struct{}{}
+		<-release
+		r.flush(batch)
+	})
+
+	go func() {
+		b.Add(1)
+		b.Add(2) // Заполняет пачку и блокируется во flush
+	}()
+	<-started
+
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		b.Add(3) // Не заполняет пачку: не должен ждать медленный flush
+	}()
+	select {
+	case <-added:
+	case <-time.After(time.Second):
+		t.Fatal("Add blocked while flush was running")
+	}
+
+	close(release)
+	b.Close()
+	if got := r.get(); !reflect.DeepEqual(got, [][]int{{1, 2}, {3}}) {
+		t.Errorf("batches = %v, expected [[1 2] [3]]", got)
+	}
+}
+
+func TestBatcherFlushSequential(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		active  int
+		overlap bool
+		total   int
+	)
+	b := NewBatcher(5, time.Millisecond, func(batch []int) {
+		mu.Lock()
+		active++
+		overlap = overlap || active > 1
+		total += len(batch)
+		mu.Unlock()
+
+		time.Sleep(100 * time.Microsecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				b.Add(i)
+			}
+		}()
+	}
+	wg.Wait()
+	b.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if overlap {
+		t.Error("flush calls overlapped, expected them to run one at a time")
+	}
+	if total != 800 {
+		t.Errorf("flushed %d items, expected 800", total)
+	}
+}
+
+func TestDrainClosedChannel(t *testing.T) {
+	ch := make(chan int, 5)
+	for i := 1; i <= 3; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	items, err := Drain(context.Background(), ch, time.Second)
+	if err != nil || !reflect.DeepEqual(items, []int{1, 2, 3}) {
+		t.Errorf("Drain() = %v, %v, expected [1 2 3], nil", items, err)
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	ch := make(chan string)
+	go func() {
+		for _, e := range []string{"login", "click"} {
+			ch <- e
+		}
+	}()
+
+	start := time.Now()
+	items, err := Drain(context.Background(), ch, 50*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Drain() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if !reflect.DeepEqual(items, []string{"login", "click"}) {
+		t.Errorf("Drain() = %q, expected values collected before the timeout", items)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Drain() took %v, expected to return after the timeout", elapsed)
+	}
+}
+
+func TestDrainCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	if _, err := Drain(ctx, ch, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("Drain() with cancelled ctx = %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestDrainNonPositiveTimeout(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		// Все буферизованные значения забираются, хотя ждать нельзя
+		buffered := make(chan int, 100)
+		for i := 0; i < 100; i++ {
+			buffered <- i
+		}
+		items, err := Drain(context.Background(), buffered, timeout)
+		if len(items) != 100 || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Drain(timeout=%v) on open channel = %d items, %v, expected 100, %v",
+				timeout, len(items), err, context.DeadlineExceeded)
+		}
+
+		closed := make(chan int, 2)
+		closed <- 1
+		close(closed)
+		if items, err := Drain(context.Background(), closed, timeout); err != nil || !reflect.DeepEqual(items, []int{1}) {
+			t.Errorf("Drain(timeout=%v) on closed channel = %v, %v, expected [1], nil", timeout, items, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Drain(ctx, make(chan int), 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Drain(timeout=0) with cancelled ctx = %v, expected %v", err, context.Canceled)
+	}
+}
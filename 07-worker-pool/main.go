@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// Пул воркеров, размер которого можно менять во время работы.
+// Задачи ждут в общей очереди, поэтому изменение размера их не теряет.
+type WorkerPool struct {
+	mu         sync.Mutex
+	tasks      chan func()
+	quit       chan struct{} // Сигнал "одному воркеру остановиться"
+	stopped    chan struct{} // Закрывается, когда после Close завершились все воркеры
+	size       int
+	closed     bool
+	wg         sync.WaitGroup
+	submitting sync.WaitGroup // Submit, которые уже прошли проверку closed и отправляют задачу
+}
+
+func NewWorkerPool(size, queueSize int) *WorkerPool {
+	p := &WorkerPool{
+		tasks:   make(chan func(), queueSize),
+		quit:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	p.Resize(size)
+	return p
+}
+
+// Добавляет задачу в очередь (блокируется, если очередь заполнена).
+// После Close возвращает ErrPoolClosed.
+func (p *WorkerPool) Submit(task func()) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+	p.submitting.Add(1)
+	p.mu.Unlock()
+
+	defer p.submitting.Done()
+	p.tasks <- task
+	return nil
+}
+
+// Меняет число воркеров. Лишние воркеры останавливаются только после завершения
+// текущей задачи, и Resize ждет их остановки; повторный вызов с тем же размером
+// ничего не делает. Размер 0 приостанавливает обработку: задачи копятся в очереди.
+// После Close возвращает ErrPoolClosed.
+func (p *WorkerPool) Resize(n int) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+	if n < 0 {
+		n = 0
+	}
+	for p.size < n {
+		p.size++
+		p.wg.Add(1)
+		go p.worker()
+	}
+	stops := p.size - n
+	p.size = n
+	p.mu.Unlock()
+
+	// Сигналы отправляются без мьютекса: задача, которую доделывает воркер,
+	// может сама вызвать Submit или Size
+	for i := 0; i < stops; i++ {
+		select {
+		case p.quit <- struct{}{}: // Примет первый воркер, закончивший текущую задачу
+		case <-p.stopped: // Close уже остановил всех воркеров
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *WorkerPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size
+}
+
+func (p *WorkerPool) worker() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case task, ok := <-p.tasks:
+			if !ok {
+				return
+			}
+			task()
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// Закрывает очередь и ждет, пока воркеры выполнят все оставшиеся задачи.
+// Если пул был приостановлен (размер 0), для оставшихся задач запускается один воркер.
+// Повторный вызов ничего не делает.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	if p.size == 0 {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	p.mu.Unlock()
+
+	// Заблокированные на полной очереди Submit дождутся места: воркеры продолжают работу
+	p.submitting.Wait()
+	close(p.tasks)
+	p.wg.Wait()
+	close(p.stopped)
+}
+
+func main() {
+	pool := NewWorkerPool(2, 100)
+
+	var completed atomic.Int32
+	submit := func(count int) {
+		for i := 0; i < count; i++ {
+			err := pool.Submit(func() {
+				time.Sleep(20 * time.Millisecond) // Имитация работы
+				completed.Add(1)
+			})
+			if err != nil {
+				fmt.Println("Submit failed:", err)
+			}
+		}
+	}
+
+	submit(20)
+	fmt.Println("Workers:", pool.Size())
+
+	// Нагрузка выросла — добавляем воркеров
+	pool.Resize(8)
+	fmt.Println("Workers after grow:", pool.Size())
+	submit(40)
+
+	// Нагрузка упала — уменьшаем пул, задачи в очереди не теряются
+	pool.Resize(3)
+	fmt.Println("Workers after shrink:", pool.Size())
+	submit(20)
+
+	pool.Resize(3) // Тот же размер — ничего не меняется
+
+	pool.Close()
+	fmt.Println("Completed tasks:", completed.Load(), "of", 80)
+
+	// После Close пул не принимает задачи и не меняет размер
+	fmt.Println("Submit after close:", pool.Submit(func() {}))
+	fmt.Println("Resize after close:", pool.Resize(1))
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolResizeUnderLoad(t *testing.T) {
+	pool := NewWorkerPool(2, 10)
+	var completed atomic.Int32
+	submit := func(count int) {
+		for i := 0; i < count; i++ {
+			err := pool.Submit(func() {
+				time.Sleep(time.Millisecond)
+				completed.Add(1)
+			})
+			if err != nil {
+				t.Fatalf("Submit() = %v, expected nil", err)
+			}
+		}
+	}
+
+	submit(20)
+	sizes := []int{8, 8, 1, 4, 0, 3}
+	for _, n := range sizes {
+		if err := pool.Resize(n); err != nil {
+			t.Fatalf("Resize(%d) = %v, expected nil", n, err)
+		}
+		if got := pool.Size(); got != n {
+			t.Errorf("Size() after Resize(%d) = %d", n, got)
+		}
+		if n > 0 {
+			submit(10)
+		}
+	}
+	pool.Close()
+
+	expected := int32(20 + 10*(len(sizes)-1))
+	if got := completed.Load(); got != expected {
+		t.Errorf("completed tasks = %d, expected %d", got, expected)
+	}
+}
+
+func TestWorkerPoolShrinkWaitsForTask(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished atomic.Bool
+	pool.Submit(func() {
+		close(started)
+		<-release
+		finished.Store(true)
+	})
+	<-started
+
+	resized := make(chan struct{})
+	go func() {
+		pool.Resize(0)
+		close(resized)
+	}()
+	select {
+	case <-resized:
+		t.Fatal("Resize(0) returned while the worker was still running a task")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-resized
+	if !finished.Load() {
+		t.Error("in-flight task did not finish before the worker stopped")
+	}
+	pool.Close()
+}
+
+func TestWorkerPoolSubmitDuringShrink(t *testing.T) {
+	pool := NewWorkerPool(2, 10)
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	var submitted, completed atomic.Int32
+	var outer sync.WaitGroup
+	outer.Add(2)
+	for i := 0; i < 2; i++ {
+		pool.Submit(func() {
+			defer outer.Done()
+			started <- struct{}{}
+			<-release
+			// Задача добавляет следующую, пока Resize ждет остановки воркеров
+			if err := pool.Submit(func() { completed.Add(1) }); err == nil {
+				submitted.Add(1)
+			}
+			pool.Size()
+		})
+	}
+	<-started
+	<-started
+
+	resized := make(chan struct{})
+	go func() {
+		pool.Resize(1)
+		close(resized)
+	}()
+	time.Sleep(10 * time.Millisecond) // Resize успевает начать ожидание воркера
+	close(release)
+
+	select {
+	case <-resized:
+	case <-time.After(time.Second):
+		t.Fatal("Resize deadlocked with a task calling Submit")
+	}
+	if got := pool.Size(); got != 1 {
+		t.Errorf("Size() after Resize(1) = %d, expected 1", got)
+	}
+	outer.Wait() // Resize дождался одного воркера, вторая задача может еще выполняться
+	pool.Close()
+	if submitted.Load() != 2 || completed.Load() != 2 {
+		t.Errorf("submitted %d and completed %d tasks from inside tasks, expected 2 and 2", submitted.Load(), completed.Load())
+	}
+}
+
+func TestWorkerPoolCloseDuringShrink(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool.Submit(func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	// Resize ждет воркера, занятого задачей; Close не должен оставить его висеть
+	resized := make(chan struct{})
+	go func() {
+		pool.Resize(0)
+		close(resized)
+	}()
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+	close(release)
+
+	for name, ch := range map[string]chan struct{}{"Resize": resized, "Close": closed} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("%s did not return", name)
+		}
+	}
+}
+
+func TestWorkerPoolCloseDrainsPausedPool(t *testing.T) {
+	pool := NewWorkerPool(0, 10)
+	var completed atomic.Int32
+	for i := 0; i < 5; i++ {
+		pool.Submit(func() { completed.Add(1) })
+	}
+
+	done := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close on a pool with zero workers did not return")
+	}
+	if got := completed.Load(); got != 5 {
+		t.Errorf("completed tasks = %d, expected 5", got)
+	}
+}
+
+func TestWorkerPoolAfterClose(t *testing.T) {
+	pool := NewWorkerPool(2, 1)
+	pool.Close()
+
+	if err := pool.Submit(func() {}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Submit after Close = %v, expected %v", err, ErrPoolClosed)
+	}
+	for _, n := range []int{0, 1, 5} {
+		if err := pool.Resize(n); !errors.Is(err, ErrPoolClosed) {
+			t.Errorf("Resize(%d) after Close = %v, expected %v", n, err, ErrPoolClosed)
+		}
+	}
+	pool.Close() // Повторный Close не паникует
+}
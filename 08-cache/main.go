@@ -0,0 +1,345 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Кэш с вытеснением давно не используемых записей (LRU) и сроком жизни (TTL) у каждой записи.
+// Запись пропадает по истечении TTL или при нехватке места — что наступит раньше.
+// Просроченная запись удаляется лениво (при Get или Sweep), поэтому до этого
+// она продолжает занимать место и учитывается в емкости.
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Начало списка — недавно использованные записи
+	items    map[K]*list.Element
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // Нулевое время — запись без срока жизни
+}
+
+func (e *cacheEntry[K, V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// capacity — наибольшее число записей; кэш без места бесполезен,
+// поэтому capacity <= 0 считается ошибкой программиста и вызывает панику
+func NewCache[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("cache: capacity must be positive")
+	}
+	return &Cache[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+// Сохраняет значение; ttl <= 0 означает запись без срока жизни
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*cacheEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&cacheEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry := el.Value.(*cacheEntry[K, V])
+	if entry.expired(time.Now()) {
+		c.removeElement(el)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// Удаляет все просроченные записи и возвращает их количество
+func (c *Cache[K, V]) Sweep() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	removed := 0
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if el.Value.(*cacheEntry[K, V]).expired(now) {
+			c.removeElement(el)
+			removed++
+		}
+		el = next
+	}
+	return removed
+}
+
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache[K, V]) removeElement(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*cacheEntry[K, V]).key)
+}
+
+// Single flight: одновременные вызовы Do с одним ключом выполняют fn один раз
+// и получают общий результат. Защищает источник данных от лавины запросов,
+// когда запись в кэше истекает и все клиенты идут за ней одновременно.
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	val     any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// Каждый вызывающий ждет результат с учетом своего контекста: при его отмене
+// Do сразу возвращает ctx.Err(). Сам fn отменяется, только когда ушли все ожидающие.
+func (g *Group) Do(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		// Контекст fn не зависит от отмены первого вызывающего, но сохраняет его значения
+		fnCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			call.val, call.err = fn(fnCtx)
+			cancel()
+
+			g.mu.Lock()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Никто не ждет: отменяем fn, а новые вызовы начнут загрузку заново
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Емкость кэша загрузчика. Редко используемые ключи вытесняются, как в обычном Cache,
+// поэтому при большем числе ключей значение может загрузиться снова раньше ttl
+const loaderCapacity = 10000
+
+// Cache-aside: значение берется из кэша, а при промахе загружается через load
+// и кладется в кэш на ttl. Одновременные промахи по одному ключу вызывают load
+// один раз (через Group). Ошибки загрузки не кэшируются — следующий Get попробует снова.
+type Loader[K comparable, V any] struct {
+	cache *Cache[K, V]
+	group Group
+	ttl   time.Duration
+	load  func(K) (V, error)
+
+	mu     sync.Mutex
+	keys   map[K]string // Ключи Group для загрузок, которые идут прямо сейчас
+	lastID uint64
+}
+
+func NewLoader[K comparable, V any](ttl time.Duration, load func(K) (V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		cache: NewCache[K, V](loaderCapacity),
+		ttl:   ttl,
+		load:  load,
+		keys:  make(map[K]string),
+	}
+}
+
+func (l *Loader[K, V]) Get(key K) (V, error) {
+	if v, ok := l.cache.Get(key); ok {
+		return v, nil
+	}
+
+	id := l.flightKey(key)
+	v, err := l.group.Do(context.Background(), id, func(ctx context.Context) (any, error) {
+		defer l.forget(key, id)
+		if v, ok := l.cache.Get(key); ok { // Пока ждали, ключ мог загрузить предыдущий полет
+			return v, nil
+		}
+		v, err := l.load(key)
+		if err != nil {
+			return nil, err
+		}
+		l.cache.Set(key, v, l.ttl)
+		return v, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return v.(V), nil
+}
+
+// Group различает вызовы по строке. Строковое представление ключа может совпасть
+// у разных ключей (int(1) и int64(1) при K = any), поэтому каждому загружаемому
+// ключу выдается свой номер, который больше никогда не используется
+func (l *Loader[K, V]) flightKey(key K) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id, ok := l.keys[key]
+	if !ok {
+		l.lastID++
+		id = strconv.FormatUint(l.lastID, 10)
+		l.keys[key] = id
+	}
+	return id
+}
+
+// Вызывается в конце полета: следующий промах по ключу начнет новый полет
+func (l *Loader[K, V]) forget(key K, id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.keys[key] == id {
+		delete(l.keys, key)
+	}
+}
+
+func main() {
+	// Вытеснение по емкости: самая давно используемая запись удаляется первой
+	cache := NewCache[string, int](2)
+	cache.Set("a", 1, 0)
+	cache.Set("b", 2, 0)
+	cache.Get("a") // "a" теперь использовалась недавно
+	cache.Set("c", 3, 0)
+	_, okA := cache.Get("a")
+	_, okB := cache.Get("b")
+	fmt.Println("After capacity eviction: a:", okA, "b:", okB)
+
+	// Истечение срока жизни
+	sessions := NewCache[string, string](10)
+	sessions.Set("token-1", "alice", 100*time.Millisecond)
+	sessions.Set("token-2", "bob", time.Minute)
+	time.Sleep(150 * time.Millisecond)
+	_, ok1 := sessions.Get("token-1")
+	user, ok2 := sessions.Get("token-2")
+	fmt.Println("After TTL: token-1:", ok1, "token-2:", user, ok2)
+
+	// Просроченные записи занимают место, пока их не удалит Sweep
+	sessions.Set("token-3", "carol", 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	fmt.Println("Before sweep:", sessions.Len(), "Swept:", sessions.Sweep(), "After sweep:", sessions.Len())
+
+	// Single flight: 10 одновременных запросов к одному ключу — одна загрузка
+	var (
+		group Group
+		loads atomic.Int32
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := group.Do(context.Background(), "user:42", func(ctx context.Context) (any, error) {
+				loads.Add(1)
+				time.Sleep(100 * time.Millisecond) // Медленный запрос к базе
+				return "alice", nil
+			})
+			if err != nil || v != "alice" {
+				fmt.Println("Unexpected result:", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Println("Loads for 10 concurrent callers:", loads.Load())
+
+	// Вызывающий со своим таймаутом не ждет дольше, чем готов
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := group.Do(ctx, "report", func(ctx context.Context) (any, error) {
+		select {
+		case <-time.After(time.Second):
+			return "report", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	fmt.Println("Impatient caller:", err)
+
+	// Cache-aside: попадания, одна загрузка на одновременные промахи, ошибки не кэшируются
+	var dbQueries atomic.Int32
+	prices := NewLoader(time.Minute, func(sku string) (float64, error) {
+		dbQueries.Add(1)
+		time.Sleep(50 * time.Millisecond) // Запрос к базе
+		if sku == "" {
+			return 0, errors.New("empty sku")
+		}
+		return 9.99, nil
+	})
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prices.Get("apple")
+		}()
+	}
+	wg.Wait()
+	price, err := prices.Get("apple")
+	fmt.Println("Price:", price, err, "DB queries:", dbQueries.Load())
+
+	prices.Get("")
+	_, err = prices.Get("")
+	fmt.Println("Failed load:", err, "DB queries:", dbQueries.Load())
+}
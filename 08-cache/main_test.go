@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheTTL(t *testing.T) {
+	c := NewCache[string, int](10)
+	c.Set("short", 1, 20*time.Millisecond)
+	c.Set("forever", 2, 0)
+
+	if v, ok := c.Get("short"); !ok || v != 1 {
+		t.Errorf("Get(short) before expiry = %d, %v, expected 1, true", v, ok)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get(short) after expiry returned a value")
+	}
+	if v, ok := c.Get("forever"); !ok || v != 2 {
+		t.Errorf("Get(forever) = %d, %v, expected 2, true", v, ok)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, expected expired entry to be removed on Get", c.Len())
+	}
+}
+
+func TestCacheCapacity(t *testing.T) {
+	c := NewCache[string, int](2)
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Get("a") // "b" становится давно не используемым
+	c.Set("c", 3, 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("least recently used key b was not evicted")
+	}
+	for key, expected := range map[string]int{"a": 1, "c": 3} {
+		if v, ok := c.Get(key); !ok || v != expected {
+			t.Errorf("Get(%s) = %d, %v, expected %d, true", key, v, ok, expected)
+		}
+	}
+
+	// Обновление существующего ключа не вытесняет другие
+	c.Set("a", 10, 0)
+	if c.Len() != 2 {
+		t.Errorf("Len() after update = %d, expected 2", c.Len())
+	}
+}
+
+func TestCacheExpiredCountsForCapacity(t *testing.T) {
+	c := NewCache[string, int](2)
+	c.Set("expiring", 1, 10*time.Millisecond)
+	c.Set("fresh", 2, 0)
+	time.Sleep(20 * time.Millisecond)
+
+	// Просроченная запись до Sweep все еще занимает место
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %d, expected the expired entry to be counted until swept", c.Len())
+	}
+	if removed := c.Sweep(); removed != 1 {
+		t.Errorf("Sweep() = %d, expected 1", removed)
+	}
+
+	c.Set("new", 3, 0)
+	for key, expected := range map[string]int{"fresh": 2, "new": 3} {
+		if v, ok := c.Get(key); !ok || v != expected {
+			t.Errorf("Get(%s) after Sweep = %d, %v, expected %d, true", key, v, ok, expected)
+		}
+	}
+}
+
+func TestNewCacheInvalidCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewCache(%d) did not panic", capacity)
+				}
+			}()
+			NewCache[string, int](capacity)
+		}()
+	}
+}
+
+// Ждет, пока к полету по ключу присоединятся n вызывающих
+func waitForWaiters(t *testing.T, g *Group, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		waiters := 0
+		if call := g.calls[key]; call != nil {
+			waiters = call.waiters
+		}
+		g.mu.Unlock()
+		if waiters == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d callers did not join the flight for %q", n, key)
+}
+
+func TestGroupDeduplicates(t *testing.T) {
+	var g Group
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (any, error) {
+		calls.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	const callers = 20
+	results := make(chan any, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := g.Do(context.Background(), "key", fn)
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+			}
+			results <- v
+		}()
+	}
+
+	waitForWaiters(t, &g, "key", callers)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn ran %d times, expected 1", got)
+	}
+	for v := range results {
+		if v != "value" {
+			t.Errorf("Do() = %v, expected \"value\"", v)
+		}
+	}
+}
+
+func TestGroupCallerCancel(t *testing.T) {
+	var g Group
+	fnCancelled := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (any, error) {
+		select {
+		case <-release:
+			return "value", nil
+		case <-ctx.Done():
+			close(fnCancelled)
+			return nil, ctx.Err()
+		}
+	}
+
+	// Второй вызывающий остается ждать: отмена первого не прерывает fn
+	second := make(chan any, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := g.Do(ctx, "key", fn)
+		first <- err
+	}()
+	waitForWaiters(t, &g, "key", 1)
+	go func() {
+		v, _ := g.Do(context.Background(), "key", fn)
+		second <- v
+	}()
+	waitForWaiters(t, &g, "key", 2)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v, expected %v", err, context.Canceled)
+	}
+	close(release)
+	if v := <-second; v != "value" {
+		t.Errorf("remaining caller got %v, expected \"value\"", v)
+	}
+	select {
+	case <-fnCancelled:
+		t.Error("fn was cancelled while another caller was still waiting")
+	default:
+	}
+}
+
+func TestGroupAllCallersCancel(t *testing.T) {
+	var g Group
+	fnCancelled := make(chan struct{})
+	fn := func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		close(fnCancelled)
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.Do(ctx, "key", fn); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	select {
+	case <-fnCancelled:
+	case <-time.After(time.Second):
+		t.Error("fn was not cancelled after the last caller left")
+	}
+}
+
+// Загрузчик, считающий обращения к источнику по ключам
+type countingSource struct {
+	mu    sync.Mutex
+	loads map[string]int
+	delay time.Duration
+	err   error
+}
+
+func (s *countingSource) load(key string) (string, error) {
+	s.mu.Lock()
+	s.loads[key]++
+	s.mu.Unlock()
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return "", s.err
+	}
+	return "value:" + key, nil
+}
+
+func (s *countingSource) count(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loads[key]
+}
+
+func TestLoaderCacheHits(t *testing.T) {
+	source := &countingSource{loads: map[string]int{}}
+	loader := NewLoader(time.Minute, source.load)
+
+	for i := 0; i < 3; i++ {
+		for _, key := range []string{"a", "b"} {
+			if v, err := loader.Get(key); err != nil || v != "value:"+key {
+				t.Errorf("Get(%q) = %q, %v, expected %q, nil", key, v, err, "value:"+key)
+			}
+		}
+	}
+	if source.count("a") != 1 || source.count("b") != 1 {
+		t.Errorf("loads = %v, expected one load per key", source.loads)
+	}
+}
+
+func TestLoaderSingleFlight(t *testing.T) {
+	source := &countingSource{loads: map[string]int{}, delay: 30 * time.Millisecond}
+	loader := NewLoader(time.Minute, source.load)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := []string{"a", "b"}[i%2]
+			if v, err := loader.Get(key); err != nil || v != "value:"+key {
+				t.Errorf("Get(%q) = %q, %v", key, v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if source.count("a") != 1 || source.count("b") != 1 {
+		t.Errorf("loads = %v, expected one load per key for concurrent misses", source.loads)
+	}
+}
+
+func TestLoaderErrorsNotCached(t *testing.T) {
+	errDown := errors.New("database is down")
+	source := &countingSource{loads: map[string]int{}, err: errDown}
+	loader := NewLoader(time.Minute, source.load)
+
+	for i := 0; i < 2; i++ {
+		if v, err := loader.Get("a"); !errors.Is(err, errDown) || v != "" {
+			t.Errorf("Get(a) = %q, %v, expected \"\", %v", v, err, errDown)
+		}
+	}
+	source.err = nil
+	if v, err := loader.Get("a"); err != nil || v != "value:a" {
+		t.Errorf("Get(a) after recovery = %q, %v, expected \"value:a\", nil", v, err)
+	}
+	if source.count("a") != 3 {
+		t.Errorf("loads = %d, expected every failed Get to retry the load", source.count("a"))
+	}
+}
+
+func TestLoaderEvictionAndTTL(t *testing.T) {
+	source := &countingSource{loads: map[string]int{}}
+	loader := NewLoader(30*time.Millisecond, source.load)
+
+	loader.Get("a")
+	for i := 0; i < loaderCapacity; i++ { // Заполняют кэш и вытесняют "a"
+		loader.Get(strconv.Itoa(i))
+	}
+	loader.Get("a")
+	if source.count("a") != 2 {
+		t.Errorf("loads of a = %d, expected reload after eviction", source.count("a"))
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	loader.Get("a")
+	if source.count("a") != 3 {
+		t.Errorf("loads of a = %d, expected reload after TTL", source.count("a"))
+	}
+}
+
+func TestLoaderStructKeys(t *testing.T) {
+	type key struct {
+		Tenant string
+		ID     int
+	}
+	var loads atomic.Int32
+	loader := NewLoader(time.Minute, func(k key) (int, error) {
+		loads.Add(1)
+		return k.ID, nil
+	})
+
+	for _, k := range []key{{"a", 1}, {"b", 1}, {"a", 1}, {"a", 2}} {
+		if v, _ := loader.Get(k); v != k.ID {
+			t.Errorf("Get(%+v) = %d, expected %d", k, v, k.ID)
+		}
+	}
+	if loads.Load() != 3 {
+		t.Errorf("loads = %d, expected 3 distinct keys", loads.Load())
+	}
+}
+
+func TestLoaderDistinctKeysSameString(t *testing.T) {
+	// int(1) и int64(1) печатаются одинаково, но это разные ключи
+	release := make(chan struct{})
+	loader := NewLoader(time.Minute, func(k any) (string, error) {
+		<-release
+		return fmt.Sprintf("%T", k), nil
+	})
+
+	keys := []any{int(1), int64(1), "1"}
+	results := make([]string, len(keys))
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = loader.Get(k)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond) // Все загрузки успевают начаться одновременно
+	close(release)
+	wg.Wait()
+
+	for i, k := range keys {
+		if expected := fmt.Sprintf("%T", k); results[i] != expected {
+			t.Errorf("Get(%#v) = %q, expected %q", k, results[i], expected)
+		}
+		if v, _ := loader.Get(k); v != results[i] {
+			t.Errorf("cached Get(%#v) = %q, expected %q", k, v, results[i])
+		}
+	}
+	if len(loader.keys) != 0 {
+		t.Errorf("keys of finished loads = %v, expected none", loader.keys)
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -85,6 +87,227 @@ func exampleGoroutineLeak() {
 	fmt.Println("Основная горутина завершена")
 }
 
+// Пример 4: Типизированные ключи для context.WithValue.
+// Ключом служит указатель на экземпляр ContextKey, поэтому два ключа никогда
+// не совпадут, даже если у них одинаковые имена (в отличие от строковых ключей).
+type ContextKey[T any] struct {
+	name string
+}
+
+func NewContextKey[T any](name string) *ContextKey[T] {
+	return &ContextKey[T]{name: name}
+}
+
+func (k *ContextKey[T]) WithValue(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+func (k *ContextKey[T]) Value(ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(k).(T)
+	return value, ok
+}
+
+func (k *ContextKey[T]) String() string {
+	return "context key " + k.name
+}
+
+var (
+	requestIDKey = NewContextKey[string]("request")
+	userIDKey    = NewContextKey[int]("user")
+	otherKey     = NewContextKey[string]("request") // То же имя, но другой ключ
+)
+
+func exampleContextValues() {
+	ctx := requestIDKey.WithValue(context.Background(), "req-42")
+	ctx = userIDKey.WithValue(ctx, 1001)
+
+	if requestID, ok := requestIDKey.Value(ctx); ok {
+		fmt.Println("Request ID:", requestID)
+	}
+	if userID, ok := userIDKey.Value(ctx); ok {
+		fmt.Println("User ID:", userID)
+	}
+	if _, ok := otherKey.Value(ctx); !ok {
+		fmt.Println("Ключ с тем же именем не видит чужое значение")
+	}
+}
+
+// Пример 5: Периодическая работа без утечек.
+// Every вызывает f на каждом тике, пока контекст не отменен. f выполняется в той же
+// горутине, поэтому к моменту возврата последний запущенный вызов f уже завершен.
+// Если контекст уже отменен, f не вызывается ни разу.
+func Every(ctx context.Context, d time.Duration, f func()) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ctx.Err() != nil { // Оба канала могли быть готовы одновременно
+				return
+			}
+			f()
+		}
+	}
+}
+
+func exampleEvery() {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	ticks := 0
+	Every(ctx, 200*time.Millisecond, func() {
+		ticks++
+		fmt.Println("Тик", ticks)
+	})
+	fmt.Println("Every завершился после отмены контекста, тиков:", ticks)
+}
+
+// Пример 7: Контекст, который отменяется при отмене любого из родителей.
+// Err() возвращает ошибку первого отмененного родителя (например, DeadlineExceeded),
+// значения ищутся по родителям по порядку, дедлайн — самый ранний из них.
+type mergedContext struct {
+	parents []context.Context
+	done    chan struct{}
+	mu      sync.Mutex
+	err     error
+	stops   []func() bool
+}
+
+func MergeContexts(ctxs ...context.Context) (context.Context, context.CancelFunc) {
+	m := &mergedContext{parents: ctxs, done: make(chan struct{})}
+	if len(ctxs) == 0 {
+		m.parents = []context.Context{context.Background()}
+	}
+
+	m.mu.Lock()
+	for _, parent := range m.parents {
+		// AfterFunc не держит отдельную горутину на каждого родителя, пока он не отменен
+		m.stops = append(m.stops, context.AfterFunc(parent, func() {
+			m.cancel(parent.Err())
+		}))
+	}
+	m.mu.Unlock()
+
+	return m, func() { m.cancel(context.Canceled) }
+}
+
+// Запоминает первую причину отмены и отписывается от всех родителей
+func (m *mergedContext) cancel(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return
+	}
+	m.err = err
+	close(m.done)
+	for _, stop := range m.stops {
+		stop()
+	}
+}
+
+func (m *mergedContext) Deadline() (deadline time.Time, ok bool) {
+	for _, parent := range m.parents {
+		if d, has := parent.Deadline(); has && (!ok || d.Before(deadline)) {
+			deadline, ok = d, true
+		}
+	}
+	return deadline, ok
+}
+
+func (m *mergedContext) Done() <-chan struct{} {
+	return m.done
+}
+
+func (m *mergedContext) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+func (m *mergedContext) Value(key any) any {
+	for _, parent := range m.parents {
+		if v := parent.Value(key); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func exampleMergeContexts() {
+	// Запрос отменяется либо клиентом, либо при остановке сервера
+	serverCtx, stopServer := context.WithCancel(context.Background())
+	defer stopServer()
+	requestCtx, cancelRequest := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancelRequest()
+
+	ctx, cancel := MergeContexts(serverCtx, requestCtx)
+	defer cancel()
+
+	<-ctx.Done()
+	fmt.Println("Объединенный контекст отменен:", ctx.Err())
+	fmt.Println("Контекст сервера все еще активен:", serverCtx.Err() == nil)
+}
+
+// Пример 8: Декоратор, добавляющий таймаут к любой функции.
+// Каждый вызов обернутой функции получает свой контекст с таймаутом d.
+// По истечении таймаута вызывающий сразу получает ErrTimeout, но сама fn
+// остановится, только если она следит за ctx; иначе она доработает в фоне.
+var ErrTimeout = errors.New("call timed out")
+
+func WithTimeout[T any](d time.Duration, fn func(context.Context) (T, error)) func() (T, error) {
+	return func() (T, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), d)
+		defer cancel()
+
+		type result struct {
+			value T
+			err   error
+		}
+		done := make(chan result, 1) // Буфер, чтобы опоздавшая fn не зависла на отправке
+		go func() {
+			v, err := fn(ctx)
+			done <- result{v, err}
+		}()
+
+		select {
+		case r := <-done:
+			return r.value, r.err
+		case <-ctx.Done():
+			var zero T
+			return zero, fmt.Errorf("%w after %v", ErrTimeout, d)
+		}
+	}
+}
+
+func exampleWithTimeout() {
+	slowQuery := func(ctx context.Context) (string, error) {
+		select {
+		case <-time.After(time.Second):
+			return "rows", nil
+		case <-ctx.Done():
+			return "", ctx.Err() // Функция учитывает отмену и завершается сразу
+		}
+	}
+
+	query := WithTimeout(100*time.Millisecond, slowQuery)
+	_, err := query()
+	fmt.Println("Медленный запрос:", err, "ErrTimeout:", errors.Is(err, ErrTimeout))
+
+	fastQuery := WithTimeout(100*time.Millisecond, func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	value, err := fastQuery()
+	fmt.Println("Быстрый запрос:", value, err)
+}
+
 func main() {
 	fmt.Println("Пример 1: Что такое контекст и зачем он нужен.")
 	exampleContextUsage()
@@ -94,4 +317,16 @@ func main() {
 
 	fmt.Println("\nПример 3: Утечка горутин из-за отсутствия отмены через контекст.")
 	exampleGoroutineLeak()
+
+	fmt.Println("\nПример 4: Типизированные ключи для значений в контексте.")
+	exampleContextValues()
+
+	fmt.Println("\nПример 5: Периодическая работа без утечек.")
+	exampleEvery()
+
+	fmt.Println("\nПример 7: Объединение нескольких контекстов.")
+	exampleMergeContexts()
+
+	fmt.Println("\nПример 8: Таймаут для любой функции.")
+	exampleWithTimeout()
 }
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestContextKey(t *testing.T) {
+	nameKey := NewContextKey[string]("name")
+	ageKey := NewContextKey[int]("age")
+	sameNameKey := NewContextKey[string]("name")
+
+	ctx := nameKey.WithValue(context.Background(), "alice")
+	ctx = ageKey.WithValue(ctx, 30)
+
+	if name, ok := nameKey.Value(ctx); !ok || name != "alice" {
+		t.Errorf("nameKey.Value() = %q, %v, expected \"alice\", true", name, ok)
+	}
+	if age, ok := ageKey.Value(ctx); !ok || age != 30 {
+		t.Errorf("ageKey.Value() = %d, %v, expected 30, true", age, ok)
+	}
+	if v, ok := sameNameKey.Value(ctx); ok {
+		t.Errorf("key with the same name saw value %q, expected no value", v)
+	}
+
+	// Значение во вложенном контексте перекрывает родительское только для своего ключа
+	child := nameKey.WithValue(ctx, "bob")
+	if name, _ := nameKey.Value(child); name != "bob" {
+		t.Errorf("nameKey.Value(child) = %q, expected \"bob\"", name)
+	}
+	if age, _ := ageKey.Value(child); age != 30 {
+		t.Errorf("ageKey.Value(child) = %d, expected 30", age)
+	}
+}
+
+func TestContextKeyMissing(t *testing.T) {
+	key := NewContextKey[int]("missing")
+	if v, ok := key.Value(context.Background()); ok || v != 0 {
+		t.Errorf("Value() on empty context = %d, %v, expected 0, false", v, ok)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 110*time.Millisecond)
+	defer cancel()
+
+	ticks := 0
+	Every(ctx, 20*time.Millisecond, func() { ticks++ })
+	// Ожидается около 5 тиков; таймер планировщика может немного запаздывать
+	if ticks < 2 || ticks > 5 {
+		t.Errorf("ticks = %d, expected between 2 and 5", ticks)
+	}
+}
+
+func TestEveryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var ticks atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Every(ctx, 5*time.Millisecond, func() {
+			if ticks.Add(1) == 3 {
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Every did not return after cancel")
+	}
+	if got := ticks.Load(); got != 3 {
+		t.Errorf("ticks = %d, expected 3", got)
+	}
+}
+
+func TestEveryCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	Every(ctx, time.Millisecond, func() { called = true })
+	if called {
+		t.Error("f was called for an already cancelled context")
+	}
+}
+
+const (
+	leakTolerance   = 1 // Допустимый прирост горутин (runtime иногда запускает служебные)
+	leakRetries     = 10
+	leakSettleDelay = 50 * time.Millisecond
+)
+
+// Пример 6: Детектор утечек горутин для тестов.
+// Сравнивает число горутин до и после fn.
+// Горутинам дается время завершиться: проверка повторяется несколько раз,
+// прежде чем сообщить об утечке.
+func AssertNoLeaks(t *testing.T, fn func()) {
+	t.Helper()
+	if before, after, leaked := countLeaked(fn); leaked {
+		t.Errorf("goroutine leak: %d before, %d after", before, after)
+	}
+}
+
+func countLeaked(fn func()) (before, after int, leaked bool) {
+	before = runtime.NumGoroutine()
+	fn()
+
+	after = runtime.NumGoroutine()
+	for i := 0; i < leakRetries && after > before+leakTolerance; i++ {
+		time.Sleep(leakSettleDelay)
+		after = runtime.NumGoroutine()
+	}
+	return before, after, after > before+leakTolerance
+}
+
+func TestAssertNoLeaks(t *testing.T) {
+	// Горутины завершаются по отмене контекста — утечки нет
+	AssertNoLeaks(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		for i := 0; i < 3; i++ {
+			go func() { <-ctx.Done() }()
+		}
+		cancel()
+	})
+}
+
+func TestLeakDetected(t *testing.T) {
+	// Горутины блокируются на канале, в который никто не пишет, до конца проверки
+	ch := make(chan int)
+	defer close(ch)
+
+	before, after, leaked := countLeaked(func() {
+		for i := 0; i < 3; i++ {
+			go func() { <-ch }()
+		}
+	})
+	if !leaked {
+		t.Errorf("leak not detected: %d before, %d after", before, after)
+	}
+}
+
+func TestMergeContexts(t *testing.T) {
+	tests := []struct {
+		name     string
+		cancel   func(cancelFirst, cancelSecond context.CancelFunc)
+		expected error
+	}{
+		{"first parent cancelled", func(first, _ context.CancelFunc) { first() }, context.Canceled},
+		{"second parent cancelled", func(_, second context.CancelFunc) { second() }, context.Canceled},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, cancelFirst := context.WithCancel(context.Background())
+			defer cancelFirst()
+			second, cancelSecond := context.WithCancel(context.Background())
+			defer cancelSecond()
+
+			ctx, cancel := MergeContexts(first, second)
+			defer cancel()
+			if ctx.Err() != nil {
+				t.Fatalf("Err() before cancellation = %v, expected nil", ctx.Err())
+			}
+
+			test.cancel(cancelFirst, cancelSecond)
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+				t.Fatal("merged context was not cancelled")
+			}
+			if ctx.Err() != test.expected {
+				t.Errorf("Err() = %v, expected %v", ctx.Err(), test.expected)
+			}
+		})
+	}
+}
+
+func TestMergeContextsDeadline(t *testing.T) {
+	slow, cancelSlow := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelSlow()
+	fast, cancelFast := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelFast()
+
+	ctx, cancel := MergeContexts(slow, fast)
+	defer cancel()
+
+	fastDeadline, _ := fast.Deadline()
+	if deadline, ok := ctx.Deadline(); !ok || !deadline.Equal(fastDeadline) {
+		t.Errorf("Deadline() = %v, %v, expected %v, true", deadline, ok, fastDeadline)
+	}
+	<-ctx.Done()
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("Err() = %v, expected %v", ctx.Err(), context.DeadlineExceeded)
+	}
+}
+
+func TestMergeContextsCancelFunc(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	defer cancelParent()
+
+	AssertNoLeaks(t, func() {
+		ctx, cancel := MergeContexts(parent, context.Background())
+		cancel()
+		<-ctx.Done()
+
+		// После отписки отмена родителя уже не меняет причину
+		cancelParent()
+		if ctx.Err() != context.Canceled {
+			t.Errorf("Err() = %v, expected %v", ctx.Err(), context.Canceled)
+		}
+	})
+}
+
+func TestMergeContextsValue(t *testing.T) {
+	key := NewContextKey[string]("user")
+	first := key.WithValue(context.Background(), "first")
+	second := key.WithValue(context.Background(), "second")
+
+	ctx, cancel := MergeContexts(context.Background(), first, second)
+	defer cancel()
+	if v, ok := key.Value(ctx); !ok || v != "first" {
+		t.Errorf("Value() = %q, %v, expected %q, true", v, ok, "first")
+	}
+
+	empty, cancelEmpty := MergeContexts()
+	defer cancelEmpty()
+	if empty.Err() != nil {
+		t.Errorf("MergeContexts() without parents: Err() = %v, expected nil", empty.Err())
+	}
+}
+
+func TestMergeContextsAlreadyCancelled(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	cancelParent()
+
+	ctx, cancel := MergeContexts(context.Background(), parent)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("merged context of a cancelled parent was not cancelled")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name     string
+		fn       func(ctx context.Context) (int, error)
+		expected int
+		err      error
+	}{
+		{"fast success", func(context.Context) (int, error) { return 1, nil }, 1, nil},
+		{"fast error", func(context.Context) (int, error) { return 0, errFailed }, 0, errFailed},
+		{"slow", func(ctx context.Context) (int, error) {
+			select {
+			case <-time.After(time.Second):
+				return 2, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}, 0, ErrTimeout},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now()
+			v, err := WithTimeout(20*time.Millisecond, test.fn)()
+			if v != test.expected || !errors.Is(err, test.err) {
+				t.Errorf("call = %d, %v, expected %d, %v", v, err, test.expected, test.err)
+			}
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("call took %v, expected to return by the timeout", elapsed)
+			}
+		})
+	}
+}
+
+func TestWithTimeoutCancelsContext(t *testing.T) {
+	stopped := make(chan error, 1)
+	call := WithTimeout(10*time.Millisecond, func(ctx context.Context) (struct{}, error) {
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return struct{}{}, ctx.Err()
+	})
+
+	if _, err := call(); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("call() = %v, expected %v", err, ErrTimeout)
+	}
+	// fn, которая следит за ctx, действительно останавливается
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("fn saw ctx.Err() = %v, expected %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wrapped function did not observe cancellation")
+	}
+}
+
+func TestWithTimeoutPerCall(t *testing.T) {
+	var calls atomic.Int32
+	call := WithTimeout(50*time.Millisecond, func(ctx context.Context) (int32, error) {
+		time.Sleep(20 * time.Millisecond)
+		return calls.Add(1), ctx.Err()
+	})
+
+	// Каждый вызов получает свой таймаут, а не делит один на всех
+	for i := int32(1); i <= 3; i++ {
+		if v, err := call(); v != i || err != nil {
+			t.Errorf("call #%d = %d, %v, expected %d, nil", i, v, err, i)
+		}
+	}
+}
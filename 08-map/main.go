@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,6 +20,9 @@ func main() {
 
 	// Пример 4: Ограничения и подводные камни sync.Map
 	example4()
+
+	// Пример 5: Генерация уникальных ключей из многих горутин
+	example5()
 }
 
 // Пример 1: Что такое sync.Map и когда его использовать
@@ -141,3 +146,75 @@ func example4() {
 	// 5. sync.Map не подходит для случаев, когда вам нужно часто обновлять одни и те же ключи,
 	// так как это может привести к contention (конкуренции за ресурсы).
 }
+
+// Пример 5: Генератор уникальных идентификаторов, безопасный для конкурентного использования
+type IDGenerator interface {
+	Next() string
+}
+
+// Последовательные ID: атомарный счетчик не выдаст одно число дважды.
+// Номер дополнен нулями, чтобы строки сортировались в порядке выдачи.
+type sequentialIDs struct {
+	counter atomic.Uint64
+}
+
+func NewSequential() IDGenerator {
+	return &sequentialIDs{}
+}
+
+func (g *sequentialIDs) Next() string {
+	return fmt.Sprintf("id-%012d", g.counter.Add(1))
+}
+
+// Случайные 128-битные ID в hex. С генератором с фиксированным seed
+// последовательность воспроизводима, что удобно в тестах.
+type randomIDs struct {
+	mu sync.Mutex // rand.Rand не безопасен для конкурентного использования
+	r  *rand.Rand
+}
+
+// nil r означает генератор, инициализированный текущим временем
+func NewRandom(r *rand.Rand) IDGenerator {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &randomIDs{r: r}
+}
+
+func (g *randomIDs) Next() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return fmt.Sprintf("%016x%016x", g.r.Uint64(), g.r.Uint64())
+}
+
+func example5() {
+	fmt.Println("\nПример 5: Генерация уникальных ключей из многих горутин")
+
+	generators := []struct {
+		name string
+		gen  IDGenerator
+	}{
+		{"Последовательные", NewSequential()},
+		{"Случайные", NewRandom(rand.New(rand.NewSource(1)))},
+	}
+	for _, g := range generators {
+		var (
+			sm         sync.Map
+			duplicates atomic.Int32
+			wg         sync.WaitGroup
+		)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if _, loaded := sm.LoadOrStore(g.gen.Next(), true); loaded {
+						duplicates.Add(1)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		fmt.Println(g.name, "ID: пример", g.gen.Next(), "повторов:", duplicates.Load())
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// Собирает count ID, сгенерированных из goroutines горутин
+func generateConcurrently(gen IDGenerator, goroutines, count int) []string {
+	var (
+		mu  sync.Mutex
+		ids []string
+		wg  sync.WaitGroup
+	)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make([]string, 0, count)
+			for j := 0; j < count; j++ {
+				local = append(local, gen.Next())
+			}
+			mu.Lock()
+			ids = append(ids, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return ids
+}
+
+func TestIDGeneratorsUniqueUnderConcurrency(t *testing.T) {
+	generators := []struct {
+		name string
+		gen  IDGenerator
+	}{
+		{"sequential", NewSequential()},
+		{"random seeded", NewRandom(rand.New(rand.NewSource(1)))},
+		{"random default", NewRandom(nil)},
+	}
+
+	for _, g := range generators {
+		t.Run(g.name, func(t *testing.T) {
+			ids := generateConcurrently(g.gen, 50, 200)
+			seen := make(map[string]bool, len(ids))
+			for _, id := range ids {
+				if seen[id] {
+					t.Fatalf("duplicate ID %q", id)
+				}
+				seen[id] = true
+			}
+			if len(seen) != 50*200 {
+				t.Errorf("generated %d unique IDs, expected %d", len(seen), 50*200)
+			}
+		})
+	}
+}
+
+func TestSequentialIDsOrder(t *testing.T) {
+	gen := NewSequential()
+	if first := gen.Next(); first != "id-000000000001" {
+		t.Errorf("first ID = %q, expected %q", first, "id-000000000001")
+	}
+
+	// Строковый порядок совпадает с порядком выдачи
+	ids := generateConcurrently(gen, 10, 100)
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	if sorted[0] != "id-000000000002" || sorted[len(sorted)-1] != "id-000000001001" {
+		t.Errorf("ID range = %q..%q, expected id-000000000002..id-000000001001", sorted[0], sorted[len(sorted)-1])
+	}
+}
+
+func TestRandomIDsDeterministic(t *testing.T) {
+	a := NewRandom(rand.New(rand.NewSource(42)))
+	b := NewRandom(rand.New(rand.NewSource(42)))
+	for i := 0; i < 5; i++ {
+		idA, idB := a.Next(), b.Next()
+		if idA != idB {
+			t.Fatalf("ID #%d with the same seed: %q and %q", i, idA, idB)
+		}
+		if len(idA) != 32 {
+			t.Errorf("ID %q has length %d, expected 32 hex digits", idA, len(idA))
+		}
+	}
+}
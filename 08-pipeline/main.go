@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Стадия конвейера: обрабатывает одно значение и может вернуть ошибку
+type Stage[T any] func(ctx context.Context, v T) (T, error)
+
+// Конвейер: каждая стадия работает в своей горутине и передает значения следующей
+// через канал. Первая ошибка любой стадии отменяет общий контекст, и все стадии завершаются.
+type Pipeline[T any] struct {
+	stages []Stage[T]
+}
+
+func NewPipeline[T any]() *Pipeline[T] {
+	return &Pipeline[T]{}
+}
+
+func (p *Pipeline[T]) AddStage(stage Stage[T]) *Pipeline[T] {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Пропускает inputs через все стадии и возвращает результаты в исходном порядке
+// либо первую возникшую ошибку. Если все значения обработаны, результаты возвращаются,
+// даже если контекст отменили сразу после этого.
+func (p *Pipeline[T]) Run(ctx context.Context, inputs []T) ([]T, error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	// Источник
+	source := make(chan T)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(source)
+		for _, v := range inputs {
+			select {
+			case source <- v:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	// Стадии соединяются каналами последовательно
+	var in <-chan T = source
+	for i, stage := range p.stages {
+		out := make(chan T)
+		wg.Add(1)
+		go func(i int, stage Stage[T], in <-chan T, out chan<- T) {
+			defer wg.Done()
+			defer close(out)
+			for v := range in {
+				result, err := stage(runCtx, v)
+				if err != nil {
+					fail(fmt.Errorf("stage %d: %w", i+1, err))
+					return
+				}
+				select {
+				case out <- result:
+				case <-runCtx.Done():
+					return
+				}
+			}
+		}(i, stage, in, out)
+		in = out
+	}
+
+	var results []T
+	for v := range in {
+		results = append(results, v)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// Все значения дошли до конца: отмена контекста после этого уже ничего не прервала
+	if len(results) == len(inputs) {
+		return results, nil
+	}
+	return nil, ctx.Err() // Отменен родительский контекст
+}
+
+func main() {
+	pipeline := NewPipeline[string]().
+		AddStage(func(ctx context.Context, s string) (string, error) {
+			return strings.TrimSpace(s), nil
+		}).
+		AddStage(func(ctx context.Context, s string) (string, error) {
+			if s == "" {
+				return "", errors.New("empty input")
+			}
+			time.Sleep(10 * time.Millisecond) // Имитация работы
+			return strings.ToUpper(s), nil
+		}).
+		AddStage(func(ctx context.Context, s string) (string, error) {
+			return "[" + s + "]", nil
+		})
+
+	results, err := pipeline.Run(context.Background(), []string{" go ", "rust", " zig"})
+	fmt.Println("Results:", results, "Error:", err)
+
+	// Вторая стадия падает на пустой строке, и весь конвейер отменяется
+	results, err = pipeline.Run(context.Background(), []string{"go", "  ", "rust", "zig"})
+	fmt.Println("Results:", results, "Error:", err)
+
+	// Отмена снаружи: таймаут меньше времени обработки
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Millisecond)
+	defer cancel()
+	results, err = pipeline.Run(ctx, []string{"a", "b", "c", "d"})
+	fmt.Println("Results:", results, "Error:", err)
+}
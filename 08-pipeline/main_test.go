@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPipeline(t *testing.T) {
+	pipeline := NewPipeline[string]().
+		AddStage(func(ctx context.Context, s string) (string, error) { return strings.TrimSpace(s), nil }).
+		AddStage(func(ctx context.Context, s string) (string, error) { return strings.ToUpper(s), nil })
+
+	tests := []struct {
+		inputs   []string
+		expected []string
+	}{
+		{[]string{" go ", "rust", " zig"}, []string{"GO", "RUST", "ZIG"}},
+		{[]string{"one"}, []string{"ONE"}},
+		{nil, nil},
+	}
+	for _, test := range tests {
+		got, err := pipeline.Run(context.Background(), test.inputs)
+		if err != nil || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Run(%q) = %q, %v, expected %q, nil", test.inputs, got, err, test.expected)
+		}
+	}
+}
+
+func TestPipelineNoStages(t *testing.T) {
+	got, err := NewPipeline[int]().Run(context.Background(), []int{1, 2, 3})
+	if err != nil || !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Run() without stages = %v, %v, expected [1 2 3], nil", got, err)
+	}
+}
+
+func TestPipelineStageError(t *testing.T) {
+	errEmpty := errors.New("empty input")
+	var processed atomic.Int32
+	pipeline := NewPipeline[string]().
+		AddStage(func(ctx context.Context, s string) (string, error) {
+			if s == "" {
+				return "", errEmpty
+			}
+			return s, nil
+		}).
+		AddStage(func(ctx context.Context, s string) (string, error) {
+			processed.Add(1)
+			return s, nil
+		})
+
+	inputs := make([]string, 100)
+	for i := range inputs {
+		inputs[i] = "x"
+	}
+	inputs[1] = ""
+
+	got, err := pipeline.Run(context.Background(), inputs)
+	if !errors.Is(err, errEmpty) || got != nil {
+		t.Errorf("Run() = %v, %v, expected nil, %v", got, err, errEmpty)
+	}
+	if !strings.Contains(err.Error(), "stage 1") {
+		t.Errorf("error %q does not name the failing stage", err)
+	}
+	if n := processed.Load(); n >= int32(len(inputs)-1) {
+		t.Errorf("second stage processed %d values after the error, expected the pipeline to stop early", n)
+	}
+}
+
+func TestPipelineMiddleStageErrorNoLeaks(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	errBad := errors.New("bad value")
+	var firstProcessed, lastProcessed atomic.Int32
+	pipeline := NewPipeline[int]().
+		AddStage(func(ctx context.Context, v int) (int, error) {
+			firstProcessed.Add(1)
+			return v * 2, nil
+		}).
+		AddStage(func(ctx context.Context, v int) (int, error) {
+			if v == 10 { // Пятое значение
+				return 0, errBad
+			}
+			return v, nil
+		}).
+		AddStage(func(ctx context.Context, v int) (int, error) {
+			lastProcessed.Add(1)
+			return v + 1, nil
+		})
+
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i + 1
+	}
+	got, err := pipeline.Run(context.Background(), inputs)
+	if !errors.Is(err, errBad) || got != nil {
+		t.Fatalf("Run() = %v, %v, expected nil, %v", got, err, errBad)
+	}
+	if !strings.Contains(err.Error(), "stage 2") {
+		t.Errorf("error %q does not name the middle stage", err)
+	}
+	// До ошибки третья стадия получила не больше четырех значений, после — ничего
+	if n := lastProcessed.Load(); n > 4 {
+		t.Errorf("last stage processed %d values, expected at most 4", n)
+	}
+	if n := firstProcessed.Load(); n >= int32(len(inputs)) {
+		t.Errorf("first stage processed %d values, expected it to stop after the error", n)
+	}
+
+	// Run ждет все горутины, но служебные горутины runtime могут завершаться с задержкой
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines: %d before, %d after Run", before, after)
+	}
+}
+
+func TestPipelineCancel(t *testing.T) {
+	pipeline := NewPipeline[int]().AddStage(func(ctx context.Context, v int) (int, error) {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			return v, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Millisecond)
+	defer cancel()
+	got, err := pipeline.Run(ctx, []int{1, 2, 3, 4, 5})
+	if !errors.Is(err, context.DeadlineExceeded) || got != nil {
+		t.Errorf("Run() = %v, %v, expected nil, %v", got, err, context.DeadlineExceeded)
+	}
+}
+
+// Контекст, который сообщает об отмене через Err, но не закрывает Done:
+// так выглядит отмена, случившаяся уже после того, как все значения обработаны
+type lateCancelledContext struct {
+	context.Context
+}
+
+func (lateCancelledContext) Err() error { return context.Canceled }
+
+func TestPipelineCancelAfterCompletion(t *testing.T) {
+	pipeline := NewPipeline[int]().AddStage(func(ctx context.Context, v int) (int, error) {
+		return v * 10, nil
+	})
+	got, err := pipeline.Run(lateCancelledContext{context.Background()}, []int{1, 2, 3})
+	if err != nil || !reflect.DeepEqual(got, []int{10, 20, 30}) {
+		t.Errorf("Run() = %v, %v, expected [10 20 30], nil", got, err)
+	}
+}
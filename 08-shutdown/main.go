@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Координатор корректного завершения: собирает хуки остановки (закрытие соединений,
+// сброс буферов и т.д.) и запускает их параллельно с общим дедлайном.
+type Shutdown struct {
+	mu    sync.Mutex
+	hooks []shutdownHook
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (s *Shutdown) Register(name string, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// Запускает все хуки параллельно и ждет их не дольше timeout.
+// Хуки, не успевшие завершиться, считаются просроченными. Все ошибки объединяются.
+func (s *Shutdown) Run(timeout time.Duration) error {
+	s.mu.Lock()
+	hooks := append([]shutdownHook(nil), s.hooks...)
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	results := make([]chan error, len(hooks))
+	for i, hook := range hooks {
+		results[i] = make(chan error, 1) // Буфер, чтобы просроченный хук не завис на отправке
+		go func(hook shutdownHook, result chan<- error) {
+			result <- hook.fn(ctx)
+		}(hook, results[i])
+	}
+
+	var errs []error
+	for i, hook := range hooks {
+		var err error
+		select {
+		case err = <-results[i]:
+		case <-ctx.Done():
+			// Хук мог завершиться одновременно с дедлайном — его результат важнее
+			select {
+			case err = <-results[i]:
+			default:
+				err = ctx.Err()
+			}
+		}
+
+		// DeadlineExceeded означает общий таймаут, только если общий дедлайн уже наступил.
+		// Иначе это собственный таймаут хука (например, запроса к базе) — обычная ошибка.
+		switch {
+		case errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil:
+			errs = append(errs, fmt.Errorf("%s: timed out after %v", hook.name, timeout))
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", hook.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func main() {
+	var shutdown Shutdown
+
+	shutdown.Register("http-server", func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond) // Дожидаемся активных запросов
+		fmt.Println("HTTP server stopped")
+		return nil
+	})
+	shutdown.Register("database", func(ctx context.Context) error {
+		return errors.New("connection already closed")
+	})
+	shutdown.Register("metrics-flush", func(ctx context.Context) error {
+		// Медленный хук, который учитывает контекст и прерывается по дедлайну
+		select {
+		case <-time.After(5 * time.Second):
+			fmt.Println("Metrics flushed")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	if err := shutdown.Run(500 * time.Millisecond); err != nil {
+		fmt.Println("Shutdown errors:")
+		fmt.Println(err)
+	}
+}
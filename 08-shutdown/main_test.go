@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	var s Shutdown
+	fastDone := make(chan struct{})
+	s.Register("fast", func(ctx context.Context) error {
+		close(fastDone)
+		return nil
+	})
+	errClosed := errors.New("already closed")
+	s.Register("failing", func(ctx context.Context) error { return errClosed })
+	s.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.Register("ignores-context", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	err := s.Run(50 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Run took %v, expected to return around the 50ms timeout", elapsed)
+	}
+
+	select {
+	case <-fastDone:
+	default:
+		t.Error("fast hook was not run")
+	}
+	if !errors.Is(err, errClosed) {
+		t.Errorf("Run() = %v, expected it to wrap %v", err, errClosed)
+	}
+	msg := err.Error()
+	for _, expected := range []string{"slow: timed out", "ignores-context: timed out", "failing: already closed"} {
+		if !strings.Contains(msg, expected) {
+			t.Errorf("Run() error %q does not contain %q", msg, expected)
+		}
+	}
+	if strings.Contains(msg, "fast") {
+		t.Errorf("Run() error %q reports the successful hook", msg)
+	}
+}
+
+func TestShutdownNoHooks(t *testing.T) {
+	var s Shutdown
+	if err := s.Run(time.Millisecond); err != nil {
+		t.Errorf("Run() with no hooks = %v, expected nil", err)
+	}
+}
+
+func TestShutdownHookOwnDeadline(t *testing.T) {
+	var s Shutdown
+	s.Register("database", func(ctx context.Context) error {
+		// Собственный короткий таймаут хука истекает задолго до общего
+		queryCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+		<-queryCtx.Done()
+		return fmt.Errorf("close connections: %w", queryCtx.Err())
+	})
+
+	err := s.Run(time.Second)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() = %v, expected it to wrap the hook's DeadlineExceeded", err)
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "timed out after") {
+		t.Errorf("Run() error %q reports the hook's own deadline as the shutdown timeout", msg)
+	}
+	if !strings.Contains(msg, "database: close connections") {
+		t.Errorf("Run() error %q does not name the hook", msg)
+	}
+}
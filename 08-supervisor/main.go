@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+var ErrTooManyRestarts = errors.New("too many restarts")
+
+// Паника воркера, превращенная в ошибку
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("worker panicked: %v", e.Value)
+}
+
+// Супервизор: запускает воркер в горутине и перезапускает его, если он
+// запаниковал или вернул ошибку. Между перезапусками задержка удваивается
+// (от Backoff до MaxBackoff; MaxBackoff <= 0 — без ограничения), чтобы постоянно
+// падающий воркер не крутился впустую. Backoff <= 0 отключает задержку совсем:
+// воркер перезапускается сразу. После MaxRestarts перезапусков подряд супервизор сдается.
+type Supervisor struct {
+	MaxRestarts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	OnRestart   func(restart int, err error) // Необязательный хук, например для логов
+}
+
+// Запускает воркер в отдельной горутине. Канал получает итог работы и закрывается:
+// nil — воркер завершился без ошибки, ctx.Err() — контекст отменен,
+// ErrTooManyRestarts (вместе с последней ошибкой воркера) — превышен лимит перезапусков.
+func (s *Supervisor) Start(ctx context.Context, worker func(ctx context.Context) error) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		defer close(result)
+		result <- s.run(ctx, worker)
+	}()
+	return result
+}
+
+func (s *Supervisor) run(ctx context.Context, worker func(ctx context.Context) error) error {
+	backoff := max(s.Backoff, 0)
+	restarts := 0
+	for {
+		err := runSafely(ctx, worker)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if restarts >= s.MaxRestarts {
+			return fmt.Errorf("%w (%d): %w", ErrTooManyRestarts, s.MaxRestarts, err)
+		}
+		restarts++
+		if s.OnRestart != nil {
+			s.OnRestart(restarts, err)
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff = s.nextBackoff(backoff)
+	}
+}
+
+func (s *Supervisor) nextBackoff(backoff time.Duration) time.Duration {
+	next := backoff * 2
+	if backoff > math.MaxInt64/2 {
+		next = math.MaxInt64 // Удвоение переполнило бы Duration
+	}
+	if s.MaxBackoff > 0 {
+		next = min(next, s.MaxBackoff)
+	}
+	return next
+}
+
+func runSafely(ctx context.Context, worker func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r}
+		}
+	}()
+	return worker(ctx)
+}
+
+func main() {
+	// Воркер падает трижды, а затем работает нормально
+	var runs atomic.Int32
+	flaky := func(ctx context.Context) error {
+		switch runs.Add(1) {
+		case 1:
+			panic("nil map write")
+		case 2:
+			return errors.New("connection reset")
+		case 3:
+			var items []int
+			_ = items[5] // Паника: выход за границы
+		}
+		fmt.Println("Worker is running normally")
+		return nil
+	}
+
+	supervisor := &Supervisor{
+		MaxRestarts: 5,
+		Backoff:     10 * time.Millisecond,
+		MaxBackoff:  100 * time.Millisecond,
+		OnRestart: func(restart int, err error) {
+			fmt.Println("Restart", restart, "after:", err)
+		},
+	}
+	fmt.Println("Flaky worker result:", <-supervisor.Start(context.Background(), flaky))
+
+	// Воркер, который падает всегда: супервизор сдается после лимита
+	supervisor.MaxRestarts = 2
+	err := <-supervisor.Start(context.Background(), func(ctx context.Context) error {
+		panic("corrupted state")
+	})
+	fmt.Println("Broken worker result:", err)
+	var panicErr *PanicError
+	fmt.Println("Gave up:", errors.Is(err, ErrTooManyRestarts), "Last panic:", errors.As(err, &panicErr))
+
+	// Отмена контекста останавливает супервизор во время ожидания перезапуска
+	supervisor.MaxRestarts = 100
+	supervisor.Backoff = time.Second
+	supervisor.OnRestart = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = <-supervisor.Start(ctx, func(ctx context.Context) error {
+		return errors.New("service unavailable")
+	})
+	fmt.Println("Cancelled supervisor result:", err)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSupervisorRestartsUntilSuccess(t *testing.T) {
+	var runs atomic.Int32
+	var restarts []int
+	supervisor := &Supervisor{
+		MaxRestarts: 5,
+		Backoff:     time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		OnRestart:   func(restart int, err error) { restarts = append(restarts, restart) },
+	}
+
+	err := <-supervisor.Start(context.Background(), func(ctx context.Context) error {
+		if runs.Add(1) <= 3 {
+			panic("crash")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Start() result = %v, expected nil", err)
+	}
+	if runs.Load() != 4 || !reflect.DeepEqual(restarts, []int{1, 2, 3}) {
+		t.Errorf("runs = %d, restarts = %v, expected 4 runs and restarts [1 2 3]", runs.Load(), restarts)
+	}
+}
+
+func TestSupervisorGivesUp(t *testing.T) {
+	for _, maxRestarts := range []int{0, 1, 3} {
+		var runs atomic.Int32
+		supervisor := &Supervisor{MaxRestarts: maxRestarts, Backoff: time.Millisecond}
+		err := <-supervisor.Start(context.Background(), func(ctx context.Context) error {
+			runs.Add(1)
+			panic("corrupted state")
+		})
+
+		var panicErr *PanicError
+		if !errors.Is(err, ErrTooManyRestarts) || !errors.As(err, &panicErr) || panicErr.Value != "corrupted state" {
+			t.Errorf("MaxRestarts %d: result = %v, expected ErrTooManyRestarts wrapping the panic", maxRestarts, err)
+		}
+		if got := runs.Load(); got != int32(maxRestarts+1) {
+			t.Errorf("MaxRestarts %d: worker ran %d times, expected %d", maxRestarts, got, maxRestarts+1)
+		}
+	}
+}
+
+func TestSupervisorCancelDuringBackoff(t *testing.T) {
+	supervisor := &Supervisor{MaxRestarts: 100, Backoff: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := <-supervisor.Start(ctx, func(ctx context.Context) error {
+		return errors.New("service unavailable")
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Start() result = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("supervisor stopped after %v, expected to stop on cancellation", elapsed)
+	}
+}
+
+func TestSupervisorUncappedBackoff(t *testing.T) {
+	// Без MaxBackoff задержки растут: 5 + 10 + 20 мс, а не обнуляются
+	supervisor := &Supervisor{MaxRestarts: 3, Backoff: 5 * time.Millisecond}
+	start := time.Now()
+	<-supervisor.Start(context.Background(), func(ctx context.Context) error {
+		return errors.New("fail")
+	})
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("three restarts took %v, expected at least 35ms of backoff", elapsed)
+	}
+}
+
+func TestSupervisorZeroBackoff(t *testing.T) {
+	for _, backoff := range []time.Duration{0, -time.Second} {
+		var runs atomic.Int32
+		supervisor := &Supervisor{MaxRestarts: 1000, Backoff: backoff}
+		start := time.Now()
+		err := <-supervisor.Start(context.Background(), func(ctx context.Context) error {
+			runs.Add(1)
+			return errors.New("fail")
+		})
+		if !errors.Is(err, ErrTooManyRestarts) || runs.Load() != 1001 {
+			t.Errorf("Backoff %v: result %v after %d runs, expected ErrTooManyRestarts after 1001", backoff, err, runs.Load())
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Backoff %v: 1000 restarts took %v, expected no delay between them", backoff, elapsed)
+		}
+	}
+}
+
+func TestSupervisorNextBackoff(t *testing.T) {
+	tests := []struct {
+		maxBackoff time.Duration
+		backoff    time.Duration
+		expected   time.Duration
+	}{
+		{0, time.Second, 2 * time.Second},
+		{-1, time.Second, 2 * time.Second},
+		{3 * time.Second, time.Second, 2 * time.Second},
+		{3 * time.Second, 2 * time.Second, 3 * time.Second},
+		{0, math.MaxInt64/2 + 1, math.MaxInt64},
+		{time.Minute, math.MaxInt64/2 + 1, time.Minute},
+	}
+
+	for _, test := range tests {
+		s := &Supervisor{MaxBackoff: test.maxBackoff}
+		if got := s.nextBackoff(test.backoff); got != test.expected {
+			t.Errorf("nextBackoff(%v) with MaxBackoff %v = %v, expected %v", test.backoff, test.maxBackoff, got, test.expected)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -151,6 +155,490 @@ func exampleWaitGroupErrors() {
 	wg.Wait() // Это приведет к deadlock, если Done не будет вызван
 }
 
+// WaitGroup, ожидание которого можно прервать через контекст
+type WaitGroupCtx struct {
+	wg sync.WaitGroup
+}
+
+func (w *WaitGroupCtx) Add(delta int) {
+	w.wg.Add(delta)
+}
+
+func (w *WaitGroupCtx) Done() {
+	w.wg.Done()
+}
+
+// Ждет завершения всех горутин или отмены контекста.
+// При отмене возвращает ctx.Err(); сами горутины при этом продолжают работу,
+// их нужно останавливать тем же контекстом.
+func (w *WaitGroupCtx) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Пример использования WaitGroupCtx: ожидание с таймаутом
+func exampleWaitGroupCtx() {
+	var wg WaitGroupCtx
+
+	for _, d := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond} {
+		wg.Add(1)
+		go func(d time.Duration) {
+			defer wg.Done()
+			time.Sleep(d)
+		}(d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fmt.Println("Fast goroutines:", wg.Wait(ctx))
+
+	// Горутина работает дольше, чем позволяет таймаут
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(2 * time.Second)
+	}()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	fmt.Println("Slow goroutine:", wg.Wait(ctx))
+}
+
+// Значение с уведомлениями об изменениях
+type Observable[T any] struct {
+	mu          sync.RWMutex
+	value       T
+	subscribers []chan T
+}
+
+// Размер буфера канала подписчика. Observable хранит состояние, а не поток событий:
+// если подписчик отстал на целый буфер, промежуточные значения для него пропускаются,
+// а актуальное всегда можно прочитать через Get. Так Set не ждет медленных подписчиков.
+const observableBufferSize = 16
+
+func NewObservable[T any](initial T) *Observable[T] {
+	return &Observable[T]{value: initial}
+}
+
+func (o *Observable[T]) Get() T {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.value
+}
+
+// Устанавливает значение и рассылает его всем подписчикам
+func (o *Observable[T]) Set(v T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.value = v
+	for _, ch := range o.subscribers {
+		select {
+		case ch <- v:
+		default: // Медленный подписчик: значение отбрасывается
+		}
+	}
+}
+
+// Возвращает канал обновлений и функцию отписки. После отписки канал закрывается
+// и больше не получает значений; повторный вызов отписки ничего не делает.
+func (o *Observable[T]) Subscribe() (<-chan T, func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	ch := make(chan T, observableBufferSize)
+	o.subscribers = append(o.subscribers, ch)
+	return ch, func() { o.unsubscribe(ch) }
+}
+
+func (o *Observable[T]) unsubscribe(ch chan T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i, sub := range o.subscribers {
+		if sub == ch {
+			o.subscribers = slices.Delete(o.subscribers, i, i+1)
+			close(ch)
+			return
+		}
+	}
+}
+
+// Пример использования Observable для реактивного состояния
+func exampleObservable() {
+	status := NewObservable("starting")
+	updates, unsubscribe := status.Subscribe()
+	defer unsubscribe()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 3; i++ {
+			fmt.Println("Status changed:", <-updates)
+		}
+	}()
+
+	status.Set("running")
+	status.Set("degraded")
+	status.Set("stopped")
+
+	wg.Wait()
+	fmt.Println("Current status:", status.Get())
+}
+
+// Счетчик для метрик: значение только растет, а Reset атомарно забирает
+// накопленное и обнуляет его. float64 хранится как биты в atomic.Uint64.
+type Gauge struct {
+	bits atomic.Uint64
+}
+
+// Отрицательные значения игнорируются: счетчик только увеличивается
+func (g *Gauge) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	for {
+		old := g.bits.Load()
+		updated := math.Float64bits(math.Float64frombits(old) + delta)
+		if g.bits.CompareAndSwap(old, updated) {
+			return
+		}
+	}
+}
+
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(g.bits.Load())
+}
+
+// Возвращает предыдущее значение и обнуляет счетчик одной атомарной операцией,
+// поэтому параллельные Add не теряются: они попадут либо в результат, либо в новое значение
+func (g *Gauge) Reset() float64 {
+	return math.Float64frombits(g.bits.Swap(0))
+}
+
+// Пример использования Gauge: сбор метрик с периодическим сбросом
+func exampleGauge() {
+	var (
+		gauge     Gauge
+		wg        sync.WaitGroup
+		collected float64
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				gauge.Add(1)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Периодически забираем накопленное, пока горутины продолжают добавлять
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		case <-time.After(time.Millisecond):
+			collected += gauge.Reset()
+		}
+	}
+
+	fmt.Println("Collected + remaining:", collected+gauge.Value(), "Expected:", 10*1000)
+}
+
+// Ленивое значение: init вызывается один раз при первом Get, даже если
+// Get одновременно вызывают несколько горутин
+type Lazy[T any] struct {
+	once  sync.Once
+	init  func() T
+	value T
+}
+
+func NewLazy[T any](init func() T) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+func (l *Lazy[T]) Get() T {
+	l.once.Do(func() {
+		l.value = l.init()
+	})
+	return l.value
+}
+
+// Пример использования Lazy для отложенной дорогой инициализации
+func exampleLazy() {
+	var initCalls atomic.Int32
+	config := NewLazy(func() map[string]string {
+		initCalls.Add(1)
+		time.Sleep(100 * time.Millisecond) // Имитация загрузки конфигурации
+		return map[string]string{"env": "production"}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = config.Get()["env"]
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println("Config env:", config.Get()["env"], "Init calls:", initCalls.Load())
+}
+
+// Семафор: ограничивает число одновременно занятых слотов
+type Semaphore struct {
+	mu       sync.Mutex
+	capacity int
+	used     int
+	released chan struct{} // Закрывается при каждом освобождении слотов, чтобы разбудить ожидающих
+}
+
+func NewSemaphore(capacity int) *Semaphore {
+	return &Semaphore{capacity: capacity, released: make(chan struct{})}
+}
+
+// Занимает один слот, ожидая его освобождения или отмены контекста
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	return s.AcquireN(ctx, 1)
+}
+
+// Занимает слот без ожидания; false, если свободных слотов нет
+func (s *Semaphore) TryAcquire() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.used < s.capacity {
+		s.used++
+		return true
+	}
+	return false
+}
+
+// Занимает n слотов атомарно: либо все сразу, либо ни одного.
+// Частичный захват мог бы привести к deadlock, если две горутины держат по половине слотов.
+// Ожидающие не выстраиваются в очередь: освободившиеся слоты забирает тот, кому их
+// хватает, поэтому большой запрос может ждать сколь угодно долго, пока мелкие
+// запросы успевают занимать слоты раньше него.
+func (s *Semaphore) AcquireN(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("semaphore: slot count must be positive, got %d", n)
+	}
+	if n > s.capacity {
+		return fmt.Errorf("semaphore: cannot acquire %d slots, capacity is %d", n, s.capacity)
+	}
+	for {
+		s.mu.Lock()
+		if s.used+n <= s.capacity {
+			s.used += n
+			s.mu.Unlock()
+			return nil
+		}
+		released := s.released
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+func (s *Semaphore) Release() {
+	s.ReleaseN(1)
+}
+
+func (s *Semaphore) ReleaseN(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n <= 0 {
+		panic("semaphore: released non-positive number of slots")
+	}
+	if n > s.used {
+		panic("semaphore: released more slots than acquired")
+	}
+	s.used -= n
+	close(s.released)
+	s.released = make(chan struct{})
+}
+
+// Взвешенный захват: емкость семафора считается в единицах ресурса (например, МБ памяти),
+// и каждая задача занимает столько, сколько ей нужно. Вес больше емкости — ошибка сразу.
+// Порядка FIFO нет (см. AcquireN): тяжелая задача может ждать, пока легкие занимают емкость.
+func (s *Semaphore) AcquireWeight(ctx context.Context, w int64) error {
+	if w <= 0 {
+		return fmt.Errorf("semaphore: weight must be positive, got %d", w)
+	}
+	if w > int64(s.capacity) { // Заодно гарантирует, что w помещается в int
+		return fmt.Errorf("semaphore: cannot acquire weight %d, capacity is %d", w, s.capacity)
+	}
+	return s.AcquireN(ctx, int(w))
+}
+
+func (s *Semaphore) ReleaseWeight(w int64) {
+	if w <= 0 {
+		panic("semaphore: released non-positive weight")
+	}
+	if w > math.MaxInt { // На 32-битных платформах int(w) молча обрезал бы вес
+		panic("semaphore: released weight overflows int")
+	}
+	s.ReleaseN(int(w))
+}
+
+// Пример использования Semaphore для неблокирующего контроля доступа
+func exampleSemaphore() {
+	sem := NewSemaphore(2)
+
+	fmt.Println("TryAcquire 1:", sem.TryAcquire())
+	fmt.Println("TryAcquire 2:", sem.TryAcquire())
+	fmt.Println("TryAcquire 3 (full):", sem.TryAcquire())
+
+	sem.Release()
+	fmt.Println("TryAcquire after release:", sem.TryAcquire())
+
+	// AcquireN ждет, пока не освободятся оба слота сразу
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		sem.ReleaseN(2)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fmt.Println("AcquireN(2):", sem.AcquireN(ctx, 2))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	fmt.Println("AcquireN(1) while full:", sem.AcquireN(ctx, 1))
+
+	// Бюджет памяти 100 МБ: задачи разного веса, суммарно в работе не больше бюджета
+	memory := NewSemaphore(100)
+	var (
+		wg          sync.WaitGroup
+		inUse, peak atomic.Int64
+	)
+	for _, mb := range []int64{60, 30, 50, 20, 70, 10} {
+		wg.Add(1)
+		go func(mb int64) {
+			defer wg.Done()
+			if err := memory.AcquireWeight(context.Background(), mb); err != nil {
+				fmt.Println("AcquireWeight:", err)
+				return
+			}
+			defer memory.ReleaseWeight(mb)
+
+			current := inUse.Add(mb)
+			for {
+				p := peak.Load()
+				if current <= p || peak.CompareAndSwap(p, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond) // Обработка, занимающая mb памяти
+			inUse.Add(-mb)
+		}(mb)
+	}
+	wg.Wait()
+	fmt.Println("Peak memory in use:", peak.Load(), "MB of 100")
+	fmt.Println("AcquireWeight(150):", memory.AcquireWeight(context.Background(), 150))
+}
+
+// Параллельный map с ограничением: одновременно выполняется не больше
+// maxConcurrency вызовов f. Результаты идут в порядке items. После первой ошибки
+// новые вызовы не запускаются, уже запущенные дорабатывают, и возвращается эта ошибка.
+func BoundedMap[T, U any](items []T, maxConcurrency int, f func(T) (U, error)) ([]U, error) {
+	sem := NewSemaphore(max(maxConcurrency, 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		results  = make([]U, len(items))
+	)
+	for i, item := range items {
+		if err := sem.Acquire(ctx); err != nil {
+			break // Контекст отменен из-за ошибки
+		}
+		if ctx.Err() != nil {
+			// Свободный слот мог достаться одновременно с отменой
+			sem.Release()
+			break
+		}
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer sem.Release()
+
+			if ctx.Err() != nil { // Ошибка случилась, пока горутина запускалась
+				return
+			}
+			u, err := f(item)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = u
+		}(i, item)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+func exampleBoundedMap() {
+	var inFlight, peak atomic.Int32
+	square := func(x int) (int, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(10-x) * 5 * time.Millisecond) // Первые элементы работают дольше
+		return x * x, nil
+	}
+
+	results, err := BoundedMap([]int{1, 2, 3, 4, 5, 6, 7, 8}, 3, square)
+	fmt.Println("Results:", results, "Error:", err, "Peak concurrency:", peak.Load())
+
+	_, err = BoundedMap([]string{"1", "2", "x", "4"}, 2, func(s string) (int, error) {
+		if s == "x" {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+		return len(s), nil
+	})
+	fmt.Println("Error:", err)
+}
+
 func main() {
 	fmt.Println("--- Example Mutex ---")
 	exampleMutex()
@@ -169,4 +657,22 @@ func main() {
 
 	fmt.Println("\n--- Example WaitGroup Errors ---")
 	// exampleWaitGroupErrors() // Раскомментируйте, чтобы увидеть ошибки
+
+	fmt.Println("\n--- Example WaitGroup with Context ---")
+	exampleWaitGroupCtx()
+
+	fmt.Println("\n--- Example Observable ---")
+	exampleObservable()
+
+	fmt.Println("\n--- Example Gauge ---")
+	exampleGauge()
+
+	fmt.Println("\n--- Example Lazy ---")
+	exampleLazy()
+
+	fmt.Println("\n--- Example Semaphore ---")
+	exampleSemaphore()
+
+	fmt.Println("\n--- Example Bounded Map ---")
+	exampleBoundedMap()
 }
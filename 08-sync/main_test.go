@@ -0,0 +1,499 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitGroupCtx(t *testing.T) {
+	var wg WaitGroupCtx
+	finished := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			time.Sleep(10 * time.Millisecond)
+			finished <- id
+		}(i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := wg.Wait(ctx); err != nil {
+		t.Fatalf("Wait() = %v, expected nil", err)
+	}
+	if len(finished) != 3 {
+		t.Errorf("Wait returned with %d of 3 goroutines finished", len(finished))
+	}
+}
+
+func TestWaitGroupCtxEmpty(t *testing.T) {
+	var wg WaitGroupCtx
+	if err := wg.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() with no goroutines = %v, expected nil", err)
+	}
+}
+
+func TestWaitGroupCtxCancel(t *testing.T) {
+	var wg WaitGroupCtx
+	release := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-release
+	}()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := wg.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait returned after %v, expected to return on cancel", elapsed)
+	}
+}
+
+func TestObservable(t *testing.T) {
+	o := NewObservable(0)
+	updates, unsubscribe := o.Subscribe()
+	defer unsubscribe()
+	for i := 1; i <= 3; i++ {
+		o.Set(i)
+	}
+
+	for expected := 1; expected <= 3; expected++ {
+		select {
+		case got := <-updates:
+			if got != expected {
+				t.Errorf("subscriber received %d, expected %d", got, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("subscriber did not receive %d", expected)
+		}
+	}
+	if got := o.Get(); got != 3 {
+		t.Errorf("Get() = %d, expected 3", got)
+	}
+}
+
+func TestObservableSlowSubscriber(t *testing.T) {
+	o := NewObservable(0)
+	o.Subscribe() // Никогда не читает и не отписывается
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= observableBufferSize*2; i++ {
+			o.Set(i)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Set blocked on a slow subscriber")
+	}
+	if got := o.Get(); got != observableBufferSize*2 {
+		t.Errorf("Get() = %d, expected %d", got, observableBufferSize*2)
+	}
+}
+
+func TestObservableConcurrentSet(t *testing.T) {
+	o := NewObservable(0)
+	updates, unsubscribe := o.Subscribe()
+	defer unsubscribe()
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			o.Set(v)
+			o.Get()
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(updates); got != 8 {
+		t.Errorf("subscriber buffered %d updates, expected 8", got)
+	}
+}
+
+func TestObservableUnsubscribe(t *testing.T) {
+	o := NewObservable(0)
+	first, unsubscribeFirst := o.Subscribe()
+	second, unsubscribeSecond := o.Subscribe()
+	defer unsubscribeSecond()
+
+	o.Set(1)
+	unsubscribeFirst()
+	unsubscribeFirst() // Повторная отписка безопасна
+	o.Set(2)
+
+	var received []int
+	for v := range first { // Канал закрыт после отписки
+		received = append(received, v)
+	}
+	if len(received) != 1 || received[0] != 1 {
+		t.Errorf("unsubscribed channel received %v, expected [1]", received)
+	}
+	if got := len(second); got != 2 {
+		t.Errorf("remaining subscriber buffered %d updates, expected 2", got)
+	}
+}
+
+func TestGauge(t *testing.T) {
+	var g Gauge
+	g.Add(1.5)
+	g.Add(2.5)
+	g.Add(-10) // Игнорируется
+	if got := g.Value(); got != 4 {
+		t.Errorf("Value() = %v, expected 4", got)
+	}
+	if got := g.Reset(); got != 4 {
+		t.Errorf("Reset() = %v, expected 4", got)
+	}
+	if got := g.Value(); got != 0 {
+		t.Errorf("Value() after Reset = %v, expected 0", got)
+	}
+}
+
+func TestGaugeConcurrentReset(t *testing.T) {
+	const writers, perWriter = 8, 1000
+	var g Gauge
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWriter; j++ {
+				g.Add(1) // Целые значения складываются во float64 без погрешности
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	collected := make(chan float64)
+	go func() {
+		sum := 0.0
+		for {
+			select {
+			case <-done:
+				collected <- sum
+				return
+			default:
+				sum += g.Reset()
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+	total := <-collected + g.Value()
+	if total != writers*perWriter {
+		t.Errorf("resets + final value = %v, expected %v", total, writers*perWriter)
+	}
+}
+
+func TestLazyConcurrentGet(t *testing.T) {
+	var initCalls atomic.Int32
+	lazy := NewLazy(func() *int {
+		initCalls.Add(1)
+		time.Sleep(10 * time.Millisecond) // Остальные Get успевают начаться до завершения init
+		v := 42
+		return &v
+	})
+
+	const goroutines = 16
+	results := make([]*int, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = lazy.Get()
+		}(i)
+	}
+	wg.Wait()
+
+	if got := initCalls.Load(); got != 1 {
+		t.Errorf("init called %d times, expected 1", got)
+	}
+	for i, r := range results {
+		if r != results[0] || *r != 42 {
+			t.Errorf("Get() in goroutine %d = %p (%d), expected the same value %p (42)", i, r, *r, results[0])
+		}
+	}
+}
+
+func TestLazyNotCalledBeforeGet(t *testing.T) {
+	called := false
+	lazy := NewLazy(func() string { called = true; return "value" })
+	if called {
+		t.Fatal("init called before Get")
+	}
+	if got := lazy.Get(); got != "value" || !called {
+		t.Errorf("Get() = %q, init called = %v, expected \"value\", true", got, called)
+	}
+}
+
+func TestSemaphoreTryAcquire(t *testing.T) {
+	s := NewSemaphore(2)
+	if !s.TryAcquire() || !s.TryAcquire() {
+		t.Fatal("TryAcquire() on a free semaphore = false, expected true")
+	}
+	if s.TryAcquire() {
+		t.Error("TryAcquire() on a full semaphore = true, expected false")
+	}
+	s.Release()
+	if !s.TryAcquire() {
+		t.Error("TryAcquire() after Release = false, expected true")
+	}
+}
+
+func TestSemaphoreAcquireNAllOrNothing(t *testing.T) {
+	s := NewSemaphore(3)
+	if err := s.AcquireN(context.Background(), 2); err != nil {
+		t.Fatalf("AcquireN(2) = %v, expected nil", err)
+	}
+
+	// Свободен один слот из трех: AcquireN(2) ждет и ничего не занимает
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.AcquireN(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AcquireN(2) on semaphore with 1 free slot = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if !s.TryAcquire() {
+		t.Error("TryAcquire() after failed AcquireN = false, expected the free slot to stay free")
+	}
+
+	// После освобождения ожидающий AcquireN получает слоты
+	s.ReleaseN(2)
+	done := make(chan error, 1)
+	go func() { done <- s.AcquireN(context.Background(), 2) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("AcquireN(2) after release = %v, expected nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AcquireN(2) did not acquire released slots")
+	}
+}
+
+func TestSemaphoreInvalidCounts(t *testing.T) {
+	s := NewSemaphore(2)
+	for _, n := range []int{0, -1, 3} {
+		if err := s.AcquireN(context.Background(), n); err == nil {
+			t.Errorf("AcquireN(%d) = nil, expected an error", n)
+		}
+	}
+
+	for _, n := range []int{0, -1, 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ReleaseN(%d) on an empty semaphore did not panic", n)
+				}
+			}()
+			s.ReleaseN(n)
+		}()
+	}
+}
+
+// Отслеживает, сколько вызовов выполняется одновременно
+type concurrencyTracker struct {
+	inFlight, peak atomic.Int32
+}
+
+func (c *concurrencyTracker) enter() {
+	current := c.inFlight.Add(1)
+	for {
+		p := c.peak.Load()
+		if current <= p || c.peak.CompareAndSwap(p, current) {
+			return
+		}
+	}
+}
+
+func (c *concurrencyTracker) leave() {
+	c.inFlight.Add(-1)
+}
+
+func TestBoundedMap(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	expected := []int{1, 4, 9, 16, 25, 36, 49, 64}
+
+	for _, limit := range []int{-1, 0, 1, 3, 8, 20} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			var tracker concurrencyTracker
+			results, err := BoundedMap(items, limit, func(x int) (int, error) {
+				tracker.enter()
+				defer tracker.leave()
+				time.Sleep(time.Duration(10-x) * time.Millisecond) // Первые элементы работают дольше
+				return x * x, nil
+			})
+
+			if err != nil || !reflect.DeepEqual(results, expected) {
+				t.Errorf("BoundedMap() = %v, %v, expected %v, nil", results, err, expected)
+			}
+			bound := int32(min(max(limit, 1), len(items)))
+			if peak := tracker.peak.Load(); peak > bound {
+				t.Errorf("peak concurrency = %d, expected at most %d", peak, bound)
+			}
+		})
+	}
+}
+
+func TestBoundedMapSequential(t *testing.T) {
+	var order []int
+	_, err := BoundedMap([]int{1, 2, 3, 4}, 1, func(x int) (int, error) {
+		order = append(order, x) // Без мьютекса: при лимите 1 вызовы не пересекаются
+		return x, nil
+	})
+	if err != nil || !reflect.DeepEqual(order, []int{1, 2, 3, 4}) {
+		t.Errorf("call order with limit 1 = %v, %v, expected [1 2 3 4], nil", order, err)
+	}
+}
+
+func TestBoundedMapError(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	var calls []int
+	results, err := BoundedMap([]int{1, 2, 3, 4, 5}, 1, func(x int) (int, error) {
+		calls = append(calls, x)
+		if x == 2 {
+			return 0, errInvalid
+		}
+		return x, nil
+	})
+
+	if !errors.Is(err, errInvalid) || results != nil {
+		t.Errorf("BoundedMap() = %v, %v, expected nil, %v", results, err, errInvalid)
+	}
+	// После ошибки новые вызовы не запускаются
+	if !reflect.DeepEqual(calls, []int{1, 2}) {
+		t.Errorf("calls = %v, expected [1 2]", calls)
+	}
+}
+
+func TestBoundedMapErrorStopsNewCalls(t *testing.T) {
+	errFailed := errors.New("failed")
+	var calls atomic.Int32
+	_, err := BoundedMap(make([]int, 100), 4, func(int) (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, errFailed
+		}
+		time.Sleep(time.Millisecond)
+		return 0, nil
+	})
+
+	if !errors.Is(err, errFailed) {
+		t.Errorf("BoundedMap() error = %v, expected %v", err, errFailed)
+	}
+	// Успевают стартовать лишь вызовы, занявшие слоты до отмены
+	if n := calls.Load(); n > 10 {
+		t.Errorf("f called %d times after the first error, expected new calls to stop", n)
+	}
+}
+
+func TestBoundedMapEmpty(t *testing.T) {
+	results, err := BoundedMap(nil, 2, func(x int) (int, error) {
+		t.Error("f called for empty input")
+		return x, nil
+	})
+	if err != nil || len(results) != 0 {
+		t.Errorf("BoundedMap(nil) = %v, %v, expected empty, nil", results, err)
+	}
+}
+
+func TestSemaphoreWeightedBound(t *testing.T) {
+	const capacity = 100
+	sem := NewSemaphore(capacity)
+	var (
+		wg          sync.WaitGroup
+		inUse, peak atomic.Int64
+	)
+
+	weights := []int64{60, 30, 50, 20, 70, 10, 100, 1, 45, 55}
+	for i := 0; i < 3; i++ {
+		for _, w := range weights {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := sem.AcquireWeight(context.Background(), w); err != nil {
+					t.Errorf("AcquireWeight(%d) = %v", w, err)
+					return
+				}
+				defer sem.ReleaseWeight(w)
+
+				current := inUse.Add(w)
+				for {
+					p := peak.Load()
+					if current <= p || peak.CompareAndSwap(p, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				inUse.Add(-w)
+			}()
+		}
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > capacity {
+		t.Errorf("peak weight in flight = %d, expected at most %d", p, capacity)
+	}
+	// Все веса освобождены: можно снова занять всю емкость
+	if !sem.TryAcquire() {
+		t.Error("semaphore is not empty after all weights were released")
+	}
+}
+
+func TestSemaphoreWeightErrors(t *testing.T) {
+	sem := NewSemaphore(10)
+	for _, w := range []int64{0, -5, 11, math.MaxInt64} {
+		start := time.Now()
+		if err := sem.AcquireWeight(context.Background(), w); err == nil {
+			t.Errorf("AcquireWeight(%d) = nil, expected an error", w)
+		}
+		if time.Since(start) > 100*time.Millisecond {
+			t.Errorf("AcquireWeight(%d) waited instead of failing immediately", w)
+		}
+	}
+
+	// Вес, который пока не помещается, ждет до отмены контекста
+	sem.AcquireWeight(context.Background(), 8)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := sem.AcquireWeight(ctx, 5); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AcquireWeight(5) with 8 of 10 in use = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	sem.ReleaseWeight(8)
+}
+
+func TestSemaphoreReleaseWeightInvalid(t *testing.T) {
+	sem := NewSemaphore(10)
+	sem.AcquireWeight(context.Background(), 3)
+
+	for _, w := range []int64{0, -1, 4, math.MaxInt64} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ReleaseWeight(%d) with weight 3 acquired did not panic", w)
+				}
+			}()
+			sem.ReleaseWeight(w)
+		}()
+	}
+	sem.ReleaseWeight(3) // Неудачные вызовы не изменили занятый вес
+}